@@ -90,14 +90,63 @@ type AuthConfig struct {
 	Token    string `yaml:"token,omitempty"`    // For bearer token auth
 }
 
+// SilencesConfig represents the silence write permissions of an Alertmanager backend
+type SilencesConfig struct {
+	WriteEnabled bool          `yaml:"write_enabled"`
+	MaxDuration  time.Duration `yaml:"max_duration,omitempty"`
+}
+
+const (
+	BackendTypePrometheus   = "prometheus"
+	BackendTypeAlertmanager = "alertmanager"
+)
+
 // BackendConfig represents the configuration of a metrics backend
 type BackendConfig struct {
+	Type          string     `yaml:"type,omitempty"` // "prometheus" (default) or "alertmanager"
 	URL           string     `yaml:"url"`
 	OrgID         string     `yaml:"org_id,omitempty"`
 	AvailableOrgs []string   `yaml:"available_orgs,omitempty"`
 	Auth          AuthConfig `yaml:"auth,omitempty"`
+
+	// TraceURLTemplate builds links to traces from exemplars (e.g., "https://tempo.example.com/trace/{{trace_id}}")
+	TraceURLTemplate string `yaml:"trace_url_template,omitempty"`
+
+	// Silences only applies to Alertmanager backends
+	Silences SilencesConfig `yaml:"silences,omitempty"`
+
+	// Limits caps the size of the query results returned for this backend
+	Limits LimitsConfig `yaml:"limits,omitempty"`
 }
 
+// LimitsConfig represents the maximum size of query results. A zero MaxSeries returns every series,
+// a zero MaxSamples falls back to the default budget
+type LimitsConfig struct {
+	MaxSeries  int `yaml:"max_series,omitempty"`
+	MaxSamples int `yaml:"max_samples,omitempty"`
+}
+
+// ToolsConfig represents the configuration shared by the MCP tools
+type ToolsConfig struct {
+	// ValidateQueries parses PromQL locally before sending it to the backend.
+	// Keep disabled for backends supporting PromQL extensions (e.g., MetricsQL)
+	ValidateQueries bool `yaml:"validate_queries,omitempty"`
+
+	// MaxPoints is the default number of points per series used to compute the step of range queries
+	// when none is provided. Defaults to 500
+	MaxPoints int `yaml:"max_points,omitempty"`
+
+	// OutputFormat is the default format of tool results: "toon" (default), "json", "csv" or "markdown"
+	OutputFormat string `yaml:"output_format,omitempty"`
+}
+
+const (
+	OutputFormatTOON     = "toon"
+	OutputFormatJSON     = "json"
+	OutputFormatCSV      = "csv"
+	OutputFormatMarkdown = "markdown"
+)
+
 // Configuration represents the complete configuration structure
 type Configuration struct {
 	Server                   ServerConfig                 `yaml:"server,omitempty"`
@@ -105,4 +154,5 @@ type Configuration struct {
 	OAuthAuthorizationServer OAuthAuthorizationServer     `yaml:"oauth_authorization_server,omitempty"`
 	OAuthProtectedResource   OAuthProtectedResourceConfig `yaml:"oauth_protected_resource,omitempty"`
 	Backends                 map[string]BackendConfig     `yaml:"backends,omitempty"`
+	Tools                    ToolsConfig                  `yaml:"tools,omitempty"`
 }
@@ -55,7 +55,8 @@ func main() {
 	case "http":
 		httpServer := server.NewStreamableHTTPServer(mcpServer,
 			server.WithHeartbeatInterval(30*time.Second),
-			server.WithStateLess(false))
+			server.WithStateLess(false),
+			server.WithHTTPContextFunc(middlewares.NewJWTSubjectContextFunc(appCtx)))
 
 		mux := http.NewServeMux()
 		mux.Handle("/mcp", accessLogsMw.Middleware(jwtValidationMw.Middleware(httpServer)))
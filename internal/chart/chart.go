@@ -0,0 +1,359 @@
+package chart
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultWidth     = 900
+	DefaultHeight    = 450
+	DefaultMaxSeries = 10
+
+	marginLeft    = 80
+	marginRight   = 24
+	marginTop     = 40
+	marginBottom  = 36
+	legendRow     = 18
+	legendPadding = 8
+	charWidth     = 7
+	yTickCount    = 5
+	xTickCount    = 6
+	maxTickCount  = 100
+
+	// flatRangeEpsilon is the relative value range below which a series is drawn as flat
+	flatRangeEpsilon = 1e-12
+)
+
+// ErrNoData is returned when there is nothing to plot
+var ErrNoData = errors.New("no data points to plot")
+
+// palette is the color of each series, in order, repeated when there are more series
+var palette = []color.RGBA{
+	{R: 0x1f, G: 0x77, B: 0xb4, A: 0xff},
+	{R: 0xff, G: 0x7f, B: 0x0e, A: 0xff},
+	{R: 0x2c, G: 0xa0, B: 0x2c, A: 0xff},
+	{R: 0xd6, G: 0x27, B: 0x28, A: 0xff},
+	{R: 0x94, G: 0x67, B: 0xbd, A: 0xff},
+	{R: 0x8c, G: 0x56, B: 0x4b, A: 0xff},
+	{R: 0xe3, G: 0x77, B: 0xc2, A: 0xff},
+	{R: 0x7f, G: 0x7f, B: 0x7f, A: 0xff},
+	{R: 0xbc, G: 0xbd, B: 0x22, A: 0xff},
+	{R: 0x17, G: 0xbe, B: 0xcf, A: 0xff},
+}
+
+// Point represents a single sample of a series
+type Point struct {
+	Time  time.Time
+	Value float64
+}
+
+// Series represents a named line of the chart
+type Series struct {
+	Name   string
+	Points []Point
+}
+
+// Options represents the presentation settings of a chart
+type Options struct {
+	Title string
+
+	// Unit is appended to the values of the Y axis (e.g., "s", "B", "%")
+	Unit string
+
+	Width     int
+	Height    int
+	MaxSeries int
+}
+
+// layout represents the computed geometry of a chart, shared by every renderer
+type layout struct {
+	options Options
+	series  []Series
+
+	// Omitted is the number of series not drawn because of MaxSeries
+	omitted int
+
+	width, height         int
+	plotLeft, plotTop     int
+	plotWidth, plotHeight int
+	minTime, maxTime      time.Time
+	minValue, maxValue    float64
+	yTicks                []float64
+	xTicks                []time.Time
+	timeLayout            string
+}
+
+// newLayout computes bounds, ticks and sizes. The height grows to fit one legend row per series
+func newLayout(series []Series, options Options) (*layout, error) {
+	if options.Width <= 0 {
+		options.Width = DefaultWidth
+	}
+	if options.Height <= 0 {
+		options.Height = DefaultHeight
+	}
+	if options.MaxSeries <= 0 {
+		options.MaxSeries = DefaultMaxSeries
+	}
+
+	l := &layout{options: options}
+	if len(series) > options.MaxSeries {
+		l.omitted = len(series) - options.MaxSeries
+		series = series[:options.MaxSeries]
+	}
+	l.series = series
+
+	first := true
+	for _, s := range series {
+		for _, p := range s.Points {
+			if math.IsNaN(p.Value) || math.IsInf(p.Value, 0) {
+				continue
+			}
+			if first {
+				l.minTime, l.maxTime = p.Time, p.Time
+				l.minValue, l.maxValue = p.Value, p.Value
+				first = false
+				continue
+			}
+			if p.Time.Before(l.minTime) {
+				l.minTime = p.Time
+			}
+			if p.Time.After(l.maxTime) {
+				l.maxTime = p.Time
+			}
+			l.minValue = math.Min(l.minValue, p.Value)
+			l.maxValue = math.Max(l.maxValue, p.Value)
+		}
+	}
+	if first {
+		return nil, ErrNoData
+	}
+
+	// Flat series and single points still need a visible range. Near-flat series of big values are padded too,
+	// as a tick step below the float precision of the values would not be representable
+	if l.maxValue-l.minValue <= math.Max(math.Abs(l.minValue), math.Abs(l.maxValue))*flatRangeEpsilon {
+		padding := math.Abs(l.minValue) * 0.1
+		if padding == 0 {
+			padding = 1
+		}
+		l.minValue -= padding
+		l.maxValue += padding
+	}
+	if !l.maxTime.After(l.minTime) {
+		l.minTime = l.minTime.Add(-time.Minute)
+		l.maxTime = l.maxTime.Add(time.Minute)
+	}
+
+	l.yTicks = niceTicks(l.minValue, l.maxValue, yTickCount)
+	l.minValue = math.Min(l.minValue, l.yTicks[0])
+	l.maxValue = math.Max(l.maxValue, l.yTicks[len(l.yTicks)-1])
+
+	l.xTicks = timeTicks(l.minTime, l.maxTime, xTickCount)
+	l.timeLayout = "15:04"
+	switch span := l.maxTime.Sub(l.minTime); {
+	case span > 365*24*time.Hour:
+		l.timeLayout = "2006-01-02"
+	case span > 24*time.Hour:
+		l.timeLayout = "01-02 15:04"
+	case span < 10*time.Minute:
+		l.timeLayout = "15:04:05"
+	}
+
+	legendRows := len(series)
+	if l.omitted > 0 {
+		legendRows++
+	}
+
+	l.width = options.Width
+	l.height = options.Height + legendRows*legendRow + legendPadding
+	l.plotLeft = marginLeft
+	l.plotTop = marginTop
+	l.plotWidth = options.Width - marginLeft - marginRight
+	l.plotHeight = options.Height - marginTop - marginBottom
+
+	return l, nil
+}
+
+// x returns the horizontal pixel of a time
+func (l *layout) x(t time.Time) float64 {
+	span := float64(l.maxTime.Sub(l.minTime))
+	return float64(l.plotLeft) + float64(t.Sub(l.minTime))/span*float64(l.plotWidth)
+}
+
+// y returns the vertical pixel of a value
+func (l *layout) y(v float64) float64 {
+	return float64(l.plotTop+l.plotHeight) - (v-l.minValue)/(l.maxValue-l.minValue)*float64(l.plotHeight)
+}
+
+// segments returns the pixel coordinates of each continuous run of valid points of a series
+func (l *layout) segments(s Series) [][][2]float64 {
+	var result [][][2]float64
+	var current [][2]float64
+	for _, p := range s.Points {
+		if math.IsNaN(p.Value) || math.IsInf(p.Value, 0) {
+			if len(current) > 0 {
+				result = append(result, current)
+				current = nil
+			}
+			continue
+		}
+		current = append(current, [2]float64{l.x(p.Time), l.y(p.Value)})
+	}
+	if len(current) > 0 {
+		result = append(result, current)
+	}
+	return result
+}
+
+// legendLabels returns the legend entries, truncated to the chart width
+func (l *layout) legendLabels() []string {
+	maxChars := (l.width - marginLeft - marginRight - 20) / charWidth
+	labels := make([]string, 0, len(l.series)+1)
+	for _, s := range l.series {
+		labels = append(labels, truncate(s.Name, maxChars))
+	}
+	if l.omitted > 0 {
+		labels = append(labels, fmt.Sprintf("... %d more series not shown", l.omitted))
+	}
+	return labels
+}
+
+// legendTop returns the vertical pixel where the legend starts
+func (l *layout) legendTop() int {
+	return l.plotTop + l.plotHeight + marginBottom
+}
+
+func seriesColor(i int) color.RGBA {
+	return palette[i%len(palette)]
+}
+
+// formatValue renders a value with an SI prefix and the unit, e.g. "1.5k", "250ms" or "20 req/s"
+func formatValue(v float64, unit string) string {
+	if len(unit) > 1 {
+		unit = " " + unit
+	}
+
+	prefixes := []struct {
+		factor float64
+		symbol string
+	}{
+		{1e12, "T"}, {1e9, "G"}, {1e6, "M"}, {1e3, "k"}, {1, ""}, {1e-3, "m"}, {1e-6, "u"}, {1e-9, "n"},
+	}
+
+	abs := math.Abs(v)
+	if abs == 0 {
+		return "0" + unit
+	}
+	for _, prefix := range prefixes {
+		if abs >= prefix.factor {
+			return trimZeros(strconv.FormatFloat(v/prefix.factor, 'f', 2, 64)) + prefix.symbol + unit
+		}
+	}
+	return strconv.FormatFloat(v, 'g', 3, 64) + unit
+}
+
+// trimZeros removes the trailing zeros of a decimal number, e.g. "1.50" becomes "1.5" and "2.00" becomes "2"
+func trimZeros(s string) string {
+	if !strings.Contains(s, ".") {
+		return s
+	}
+	return strings.TrimSuffix(strings.TrimRight(s, "0"), ".")
+}
+
+// niceTicks returns around count evenly spaced round values covering [min, max].
+// Ranges that cannot be split into round steps only get their bounds as ticks
+func niceTicks(minValue, maxValue float64, count int) []float64 {
+	step := niceNumber((maxValue-minValue)/float64(count-1), true)
+	start := math.Floor(minValue/step) * step
+	end := math.Ceil(maxValue/step) * step
+
+	n := int(math.Round((end - start) / step))
+	if step <= math.Abs(end)*flatRangeEpsilon || n < 0 || n > maxTickCount {
+		return []float64{minValue, maxValue}
+	}
+
+	ticks := make([]float64, 0, n+1)
+	for i := 0; i <= n; i++ {
+		// Avoid accumulated floating point noise like 0.30000000000000004
+		ticks = append(ticks, math.Round((start+float64(i)*step)/step)*step)
+	}
+	return ticks
+}
+
+// niceNumber returns a round number (1, 2, 5 or 10 times a power of ten) close to the given one
+func niceNumber(value float64, round bool) float64 {
+	if value <= 0 {
+		return 1
+	}
+	exponent := math.Floor(math.Log10(value))
+	fraction := value / math.Pow(10, exponent)
+
+	var nice float64
+	switch {
+	case round && fraction < 1.5, !round && fraction <= 1:
+		nice = 1
+	case round && fraction < 3, !round && fraction <= 2:
+		nice = 2
+	case round && fraction < 7, !round && fraction <= 5:
+		nice = 5
+	default:
+		nice = 10
+	}
+	return nice * math.Pow(10, exponent)
+}
+
+// timeTicks returns around count round times covering [start, end]
+func timeTicks(start, end time.Time, count int) []time.Time {
+	steps := []time.Duration{
+		time.Second, 5 * time.Second, 15 * time.Second, 30 * time.Second,
+		time.Minute, 5 * time.Minute, 15 * time.Minute, 30 * time.Minute,
+		time.Hour, 3 * time.Hour, 6 * time.Hour, 12 * time.Hour,
+		24 * time.Hour, 7 * 24 * time.Hour, 30 * 24 * time.Hour, 365 * 24 * time.Hour,
+	}
+
+	target := end.Sub(start) / time.Duration(count)
+	step := steps[len(steps)-1]
+	for _, candidate := range steps {
+		if candidate >= target {
+			step = candidate
+			break
+		}
+	}
+
+	// Align to the step in the location of the times, so daily ticks land on midnight
+	_, offset := start.Zone()
+	zoneShift := time.Duration(offset) * time.Second
+	first := start.Add(zoneShift).Truncate(step).Add(-zoneShift)
+	if first.Before(start) {
+		first = first.Add(step)
+	}
+
+	ticks := []time.Time{}
+	for t := first; !t.After(end); t = t.Add(step) {
+		ticks = append(ticks, t)
+	}
+	return ticks
+}
+
+func truncate(s string, maxChars int) string {
+	runes := []rune(s)
+	if maxChars <= 3 || len(runes) <= maxChars {
+		return s
+	}
+	return string(runes[:maxChars-3]) + "..."
+}
+
+// escapeText keeps only printable characters, as the fonts used do not cover control characters
+func escapeText(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < 0x20 {
+			return ' '
+		}
+		return r
+	}, s)
+}
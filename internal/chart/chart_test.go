@@ -0,0 +1,127 @@
+package chart
+
+import (
+	"bytes"
+	"errors"
+	"image/png"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testSeries(count int) []Series {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	series := make([]Series, 0, count)
+	for i := 0; i < count; i++ {
+		s := Series{Name: `{instance="node-` + string(rune('a'+i)) + `"}`}
+		for j := 0; j < 60; j++ {
+			value := float64(i*10 + j)
+			if j == 30 {
+				value = math.NaN()
+			}
+			s.Points = append(s.Points, Point{Time: start.Add(time.Duration(j) * time.Minute), Value: value})
+		}
+		series = append(series, s)
+	}
+	return series
+}
+
+func TestRenderPNG(t *testing.T) {
+	data, err := RenderPNG(testSeries(3), Options{Title: "rate(http_requests_total[5m])", Unit: "req/s", Width: 600, Height: 300})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("invalid PNG: %v", err)
+	}
+
+	// The legend adds one row per series below the plot
+	if got := img.Bounds().Size(); got.X != 600 || got.Y != 300+3*legendRow+legendPadding {
+		t.Errorf("size = %v, want 600x%d", got, 300+3*legendRow+legendPadding)
+	}
+}
+
+func TestRenderSVG(t *testing.T) {
+	data, err := RenderSVG(testSeries(12), Options{Title: "a < b", MaxSeries: 10})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	svg := string(data)
+
+	if !strings.Contains(svg, "a &lt; b") {
+		t.Errorf("title not escaped: %s", svg)
+	}
+	// Every series is split in two by the NaN sample
+	if got := strings.Count(svg, `stroke-width="2"`); got != 20 {
+		t.Errorf("series polylines = %d, want 20", got)
+	}
+	if !strings.Contains(svg, "2 more series not shown") {
+		t.Error("missing omitted series note")
+	}
+}
+
+func TestRenderNoData(t *testing.T) {
+	_, err := RenderPNG([]Series{{Name: "empty", Points: []Point{{Time: time.Now(), Value: math.NaN()}}}}, Options{})
+	if !errors.Is(err, ErrNoData) {
+		t.Errorf("error = %v, want ErrNoData", err)
+	}
+}
+
+func TestRenderNearFlatBigValues(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	series := []Series{{Name: "ns", Points: []Point{
+		{Time: start, Value: 1e17},
+		{Time: start.Add(time.Minute), Value: math.Nextafter(1e17, math.Inf(1))},
+	}}}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := RenderSVG(series, Options{})
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("rendering did not finish")
+	}
+}
+
+func TestNiceTicks(t *testing.T) {
+	ticks := niceTicks(0, 9, 5)
+	if len(ticks) != 6 || ticks[0] != 0 || ticks[5] != 10 {
+		t.Errorf("niceTicks(0, 9) = %v, want 0..10 by 2", ticks)
+	}
+
+	// The step is below the float spacing of the values, only the bounds are returned
+	if ticks := niceTicks(1e17, math.Nextafter(1e17, math.Inf(1)), 5); len(ticks) != 2 {
+		t.Errorf("got %d ticks, want 2", len(ticks))
+	}
+}
+
+func TestFormatValue(t *testing.T) {
+	tests := []struct {
+		value float64
+		unit  string
+		want  string
+	}{
+		{0, "s", "0s"},
+		{0.25, "s", "250ms"},
+		{1500, "", "1.5k"},
+		{-2e9, "B", "-2GB"},
+		{42, "%", "42%"},
+		{20, "req/s", "20 req/s"},
+	}
+
+	for _, tt := range tests {
+		if got := formatValue(tt.value, tt.unit); got != tt.want {
+			t.Errorf("formatValue(%v, %q) = %q, want %q", tt.value, tt.unit, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package chart
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+var (
+	backgroundColor = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	gridColor       = color.RGBA{R: 0xe5, G: 0xe5, B: 0xe5, A: 0xff}
+	axisColor       = color.RGBA{R: 0x60, G: 0x60, B: 0x60, A: 0xff}
+	textColor       = color.RGBA{R: 0x20, G: 0x20, B: 0x20, A: 0xff}
+)
+
+// RenderPNG draws the series as a line chart and returns it encoded as PNG
+func RenderPNG(series []Series, options Options) ([]byte, error) {
+	l, err := newLayout(series, options)
+	if err != nil {
+		return nil, err
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, l.width, l.height))
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: backgroundColor}, image.Point{}, draw.Src)
+
+	face := basicfont.Face7x13
+	plotBottom := l.plotTop + l.plotHeight
+	plotRight := l.plotLeft + l.plotWidth
+
+	// Title
+	if l.options.Title != "" {
+		title := truncate(escapeText(l.options.Title), l.width/charWidth-2)
+		drawText(img, face, title, (l.width-textWidth(face, title))/2, marginTop/2+5, textColor)
+	}
+
+	// Horizontal grid and Y axis labels
+	for _, tick := range l.yTicks {
+		y := int(math.Round(l.y(tick)))
+		drawLine(img, float64(l.plotLeft), float64(y), float64(plotRight), float64(y), gridColor, 1)
+		label := formatValue(tick, l.options.Unit)
+		drawText(img, face, label, l.plotLeft-8-textWidth(face, label), y+4, textColor)
+	}
+
+	// Vertical grid and X axis labels
+	for _, tick := range l.xTicks {
+		x := int(math.Round(l.x(tick)))
+		drawLine(img, float64(x), float64(l.plotTop), float64(x), float64(plotBottom), gridColor, 1)
+		label := tick.Format(l.timeLayout)
+		drawText(img, face, label, x-textWidth(face, label)/2, plotBottom+18, textColor)
+	}
+
+	// Axes
+	drawLine(img, float64(l.plotLeft), float64(l.plotTop), float64(l.plotLeft), float64(plotBottom), axisColor, 1)
+	drawLine(img, float64(l.plotLeft), float64(plotBottom), float64(plotRight), float64(plotBottom), axisColor, 1)
+
+	// Series
+	for i, s := range l.series {
+		lineColor := seriesColor(i)
+		for _, segment := range l.segments(s) {
+			if len(segment) == 1 {
+				drawLine(img, segment[0][0]-1, segment[0][1], segment[0][0]+1, segment[0][1], lineColor, 2)
+				continue
+			}
+			for j := 1; j < len(segment); j++ {
+				drawLine(img, segment[j-1][0], segment[j-1][1], segment[j][0], segment[j][1], lineColor, 2)
+			}
+		}
+	}
+
+	// Legend
+	for i, label := range l.legendLabels() {
+		y := l.legendTop() + i*legendRow
+		if i < len(l.series) {
+			draw.Draw(img, image.Rect(l.plotLeft, y+3, l.plotLeft+12, y+13), &image.Uniform{C: seriesColor(i)}, image.Point{}, draw.Src)
+		}
+		drawText(img, face, escapeText(label), l.plotLeft+20, y+12, textColor)
+	}
+
+	var buffer bytes.Buffer
+	if err := png.Encode(&buffer, img); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// drawLine draws a line between two points, stepping one pixel at a time along its longest axis
+func drawLine(img *image.RGBA, x0, y0, x1, y1 float64, c color.RGBA, thickness int) {
+	steps := int(math.Ceil(math.Max(math.Abs(x1-x0), math.Abs(y1-y0))))
+	if steps == 0 {
+		steps = 1
+	}
+
+	for i := 0; i <= steps; i++ {
+		t := float64(i) / float64(steps)
+		x := int(math.Round(x0 + (x1-x0)*t))
+		y := int(math.Round(y0 + (y1-y0)*t))
+		for dx := 0; dx < thickness; dx++ {
+			for dy := 0; dy < thickness; dy++ {
+				img.SetRGBA(x+dx, y+dy, c)
+			}
+		}
+	}
+}
+
+// drawText writes a string with its baseline starting at the given point
+func drawText(img *image.RGBA, face font.Face, text string, x, y int, c color.RGBA) {
+	drawer := &font.Drawer{
+		Dst:  img,
+		Src:  &image.Uniform{C: c},
+		Face: face,
+		Dot:  fixed.P(x, y),
+	}
+	drawer.DrawString(text)
+}
+
+func textWidth(face font.Face, text string) int {
+	return font.MeasureString(face, text).Round()
+}
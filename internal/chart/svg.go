@@ -0,0 +1,84 @@
+package chart
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"image/color"
+	"strconv"
+	"strings"
+)
+
+// RenderSVG draws the series as a line chart and returns it as an SVG document
+func RenderSVG(series []Series, options Options) ([]byte, error) {
+	l, err := newLayout(series, options)
+	if err != nil {
+		return nil, err
+	}
+
+	plotBottom := l.plotTop + l.plotHeight
+	plotRight := l.plotLeft + l.plotWidth
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="12">`+"\n",
+		l.width, l.height, l.width, l.height)
+	fmt.Fprintf(&b, `<rect width="100%%" height="100%%" fill="%s"/>`+"\n", hexColor(backgroundColor))
+
+	if l.options.Title != "" {
+		fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="middle" font-size="14" font-weight="bold" fill="%s">%s</text>`+"\n",
+			l.width/2, marginTop/2+5, hexColor(textColor), escapeXML(l.options.Title))
+	}
+
+	for _, tick := range l.yTicks {
+		y := formatCoordinate(l.y(tick))
+		fmt.Fprintf(&b, `<line x1="%d" y1="%s" x2="%d" y2="%s" stroke="%s"/>`+"\n", l.plotLeft, y, plotRight, y, hexColor(gridColor))
+		fmt.Fprintf(&b, `<text x="%d" y="%s" dy="4" text-anchor="end" fill="%s">%s</text>`+"\n",
+			l.plotLeft-8, y, hexColor(textColor), escapeXML(formatValue(tick, l.options.Unit)))
+	}
+
+	for _, tick := range l.xTicks {
+		x := formatCoordinate(l.x(tick))
+		fmt.Fprintf(&b, `<line x1="%s" y1="%d" x2="%s" y2="%d" stroke="%s"/>`+"\n", x, l.plotTop, x, plotBottom, hexColor(gridColor))
+		fmt.Fprintf(&b, `<text x="%s" y="%d" text-anchor="middle" fill="%s">%s</text>`+"\n",
+			x, plotBottom+18, hexColor(textColor), tick.Format(l.timeLayout))
+	}
+
+	fmt.Fprintf(&b, `<polyline points="%d,%d %d,%d %d,%d" fill="none" stroke="%s"/>`+"\n",
+		l.plotLeft, l.plotTop, l.plotLeft, plotBottom, plotRight, plotBottom, hexColor(axisColor))
+
+	for i, s := range l.series {
+		for _, segment := range l.segments(s) {
+			points := make([]string, 0, len(segment))
+			for _, point := range segment {
+				points = append(points, formatCoordinate(point[0])+","+formatCoordinate(point[1]))
+			}
+			fmt.Fprintf(&b, `<polyline points="%s" fill="none" stroke="%s" stroke-width="2" stroke-linejoin="round"><title>%s</title></polyline>`+"\n",
+				strings.Join(points, " "), hexColor(seriesColor(i)), escapeXML(s.Name))
+		}
+	}
+
+	for i, label := range l.legendLabels() {
+		y := l.legendTop() + i*legendRow
+		if i < len(l.series) {
+			fmt.Fprintf(&b, `<rect x="%d" y="%d" width="12" height="10" fill="%s"/>`+"\n", l.plotLeft, y+3, hexColor(seriesColor(i)))
+		}
+		fmt.Fprintf(&b, `<text x="%d" y="%d" fill="%s">%s</text>`+"\n", l.plotLeft+20, y+12, hexColor(textColor), escapeXML(label))
+	}
+
+	b.WriteString("</svg>\n")
+	return b.Bytes(), nil
+}
+
+func hexColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+func formatCoordinate(v float64) string {
+	return strconv.FormatFloat(v, 'f', 1, 64)
+}
+
+func escapeXML(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(escapeText(s)))
+	return b.String()
+}
@@ -1,8 +1,10 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"prometheus-mcp/api"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
@@ -14,9 +16,44 @@ func Marshal(config api.Configuration) ([]byte, error) {
 func Unmarshal(bytes []byte) (api.Configuration, error) {
 	var config api.Configuration
 	err := yaml.Unmarshal(bytes, &config)
+	if err != nil {
+		return config, err
+	}
+
+	err = setBackendDefaults(&config)
+	if err != nil {
+		return config, err
+	}
+
+	err = validateTools(&config)
 	return config, err
 }
 
+// setBackendDefaults fills the backend type when omitted and rejects unknown ones
+func setBackendDefaults(config *api.Configuration) error {
+	for name, backend := range config.Backends {
+		switch backend.Type {
+		case "":
+			backend.Type = api.BackendTypePrometheus
+		case api.BackendTypePrometheus, api.BackendTypeAlertmanager:
+		default:
+			return fmt.Errorf("backend %q has unknown type %q", name, backend.Type)
+		}
+		config.Backends[name] = backend
+	}
+	return nil
+}
+
+// validateTools rejects unknown default output formats, which would make every tool call fail
+func validateTools(config *api.Configuration) error {
+	switch strings.ToLower(config.Tools.OutputFormat) {
+	case "", api.OutputFormatTOON, api.OutputFormatJSON, api.OutputFormatCSV, api.OutputFormatMarkdown:
+		return nil
+	default:
+		return fmt.Errorf("tools has unknown output_format %q", config.Tools.OutputFormat)
+	}
+}
+
 // ReadFile reads and parses a configuration file, expanding environment variables.
 // Supports ${VAR} and $VAR syntax for environment variable expansion.
 func ReadFile(filepath string) (api.Configuration, error) {
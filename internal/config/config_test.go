@@ -196,3 +196,57 @@ backends:
 		t.Errorf("thanos token = %q, want %q", thanos.Auth.Token, "my-token")
 	}
 }
+
+func TestBackendType(t *testing.T) {
+	yaml := `
+backends:
+  prometheus:
+    url: "http://localhost:9090"
+  alertmanager:
+    type: "alertmanager"
+    url: "http://alertmanager:9093"
+`
+
+	config, err := Unmarshal([]byte(yaml))
+	if err != nil {
+		t.Fatalf("failed to unmarshal yaml: %v", err)
+	}
+
+	if got := config.Backends["prometheus"].Type; got != "prometheus" {
+		t.Errorf("prometheus type = %q, want %q", got, "prometheus")
+	}
+	if got := config.Backends["alertmanager"].Type; got != "alertmanager" {
+		t.Errorf("alertmanager type = %q, want %q", got, "alertmanager")
+	}
+
+	_, err = Unmarshal([]byte(`
+backends:
+  loki:
+    type: "loki"
+    url: "http://loki:3100"
+`))
+	if err == nil {
+		t.Error("expected error for unknown backend type")
+	}
+}
+
+func TestToolsOutputFormat(t *testing.T) {
+	config, err := Unmarshal([]byte(`
+tools:
+  output_format: "json"
+`))
+	if err != nil {
+		t.Fatalf("failed to unmarshal yaml: %v", err)
+	}
+	if got := config.Tools.OutputFormat; got != "json" {
+		t.Errorf("output format = %q, want %q", got, "json")
+	}
+
+	_, err = Unmarshal([]byte(`
+tools:
+  output_format: "jsno"
+`))
+	if err == nil {
+		t.Error("expected error for unknown output format")
+	}
+}
@@ -10,16 +10,22 @@ import (
 )
 
 type ApplicationContext struct {
-	Context context.Context
-	Logger  *slog.Logger
-	Config  *api.Configuration
+	Context     context.Context
+	Logger      *slog.Logger
+	AuditLogger *slog.Logger
+	Config      *api.Configuration
 }
 
 func NewApplicationContext() (*ApplicationContext, error) {
 
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, nil))
+
 	appCtx := &ApplicationContext{
 		Context: context.Background(),
-		Logger:  slog.New(slog.NewJSONHandler(os.Stderr, nil)),
+		Logger:  logger,
+
+		// Write operations performed against backends are logged here
+		AuditLogger: logger.With("log_type", "audit"),
 	}
 
 	// Parse and store the config
@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+
+	httptransport "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+	amclient "github.com/prometheus/alertmanager/api/v2/client"
+	"github.com/prometheus/alertmanager/api/v2/client/alert"
+	"github.com/prometheus/alertmanager/api/v2/client/general"
+	"github.com/prometheus/alertmanager/api/v2/client/silence"
+	"github.com/prometheus/alertmanager/api/v2/models"
+)
+
+// AlertsFilter represents the optional filters supported by the Alertmanager alerts endpoint
+type AlertsFilter struct {
+	Matchers  []string
+	Receiver  string
+	Active    *bool
+	Silenced  *bool
+	Inhibited *bool
+}
+
+// newAlertmanagerClient builds an Alertmanager v2 API client that sends every request through the given transport
+func newAlertmanagerClient(address string, transport http.RoundTripper) (*amclient.AlertmanagerAPI, error) {
+	u, err := url.Parse(address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL: %w", err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid URL %q: scheme and host are required", address)
+	}
+
+	rt := httptransport.NewWithClient(
+		u.Host,
+		path.Join(u.Path, amclient.DefaultBasePath),
+		[]string{u.Scheme},
+		&http.Client{Transport: transport},
+	)
+
+	return amclient.New(rt, strfmt.Default), nil
+}
+
+func (hm *HandlersManager) GetAlertmanagerClient(backendName string) (*amclient.AlertmanagerAPI, error) {
+	client, ok := hm.AlertmanagerClients[backendName]
+	if !ok {
+		return nil, fmt.Errorf("alertmanager backend %q not initialized", backendName)
+	}
+	return client, nil
+}
+
+func (hm *HandlersManager) AlertmanagerAlerts(ctx context.Context, backendName string, filter AlertsFilter, orgID string) (models.GettableAlerts, error) {
+	client, err := hm.GetAlertmanagerClient(backendName)
+	if err != nil {
+		return nil, err
+	}
+
+	if orgID != "" {
+		ctx = context.WithValue(ctx, "org_id", orgID)
+	}
+
+	params := alert.NewGetAlertsParamsWithContext(ctx)
+	params.Filter = filter.Matchers
+	params.Active = filter.Active
+	params.Silenced = filter.Silenced
+	params.Inhibited = filter.Inhibited
+	if filter.Receiver != "" {
+		params.Receiver = &filter.Receiver
+	}
+
+	result, err := client.Alert.GetAlerts(params)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching alerts: %w", err)
+	}
+
+	return result.Payload, nil
+}
+
+func (hm *HandlersManager) AlertmanagerSilences(ctx context.Context, backendName string, matchers []string, orgID string) (models.GettableSilences, error) {
+	client, err := hm.GetAlertmanagerClient(backendName)
+	if err != nil {
+		return nil, err
+	}
+
+	if orgID != "" {
+		ctx = context.WithValue(ctx, "org_id", orgID)
+	}
+
+	params := silence.NewGetSilencesParamsWithContext(ctx)
+	params.Filter = matchers
+
+	result, err := client.Silence.GetSilences(params)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching silences: %w", err)
+	}
+
+	return result.Payload, nil
+}
+
+func (hm *HandlersManager) AlertmanagerSilence(ctx context.Context, backendName string, silenceID string, orgID string) (*models.GettableSilence, error) {
+	client, err := hm.GetAlertmanagerClient(backendName)
+	if err != nil {
+		return nil, err
+	}
+
+	if orgID != "" {
+		ctx = context.WithValue(ctx, "org_id", orgID)
+	}
+
+	params := silence.NewGetSilenceParamsWithContext(ctx).WithSilenceID(strfmt.UUID(silenceID))
+
+	result, err := client.Silence.GetSilence(params)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching silence: %w", err)
+	}
+
+	return result.Payload, nil
+}
+
+func (hm *HandlersManager) CreateAlertmanagerSilence(ctx context.Context, backendName string, postable *models.PostableSilence, orgID string) (string, error) {
+	client, err := hm.GetAlertmanagerClient(backendName)
+	if err != nil {
+		return "", err
+	}
+
+	if orgID != "" {
+		ctx = context.WithValue(ctx, "org_id", orgID)
+	}
+
+	params := silence.NewPostSilencesParamsWithContext(ctx).WithSilence(postable)
+
+	result, err := client.Silence.PostSilences(params)
+	if err != nil {
+		return "", fmt.Errorf("error creating silence: %w", err)
+	}
+
+	return result.Payload.SilenceID, nil
+}
+
+func (hm *HandlersManager) ExpireAlertmanagerSilence(ctx context.Context, backendName string, silenceID string, orgID string) error {
+	client, err := hm.GetAlertmanagerClient(backendName)
+	if err != nil {
+		return err
+	}
+
+	if orgID != "" {
+		ctx = context.WithValue(ctx, "org_id", orgID)
+	}
+
+	params := silence.NewDeleteSilenceParamsWithContext(ctx).WithSilenceID(strfmt.UUID(silenceID))
+
+	if _, err := client.Silence.DeleteSilence(params); err != nil {
+		return fmt.Errorf("error expiring silence: %w", err)
+	}
+
+	return nil
+}
+
+func (hm *HandlersManager) AlertmanagerStatus(ctx context.Context, backendName string, orgID string) (*models.AlertmanagerStatus, error) {
+	client, err := hm.GetAlertmanagerClient(backendName)
+	if err != nil {
+		return nil, err
+	}
+
+	if orgID != "" {
+		ctx = context.WithValue(ctx, "org_id", orgID)
+	}
+
+	result, err := client.General.GetStatus(general.NewGetStatusParamsWithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("error fetching status: %w", err)
+	}
+
+	return result.Payload, nil
+}
@@ -10,8 +10,10 @@ import (
 	"prometheus-mcp/internal/globals"
 	"time"
 
+	amclient "github.com/prometheus/alertmanager/api/v2/client"
 	prometheusapi "github.com/prometheus/client_golang/api"
 	v1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
 )
 
 type HandlersManagerDependencies struct {
@@ -19,14 +21,16 @@ type HandlersManagerDependencies struct {
 }
 
 type HandlersManager struct {
-	dependencies HandlersManagerDependencies
-	Clients      map[string]v1.API
+	dependencies        HandlersManagerDependencies
+	Clients             map[string]v1.API
+	AlertmanagerClients map[string]*amclient.AlertmanagerAPI
 }
 
 func NewHandlersManager(deps HandlersManagerDependencies) *HandlersManager {
 	hm := &HandlersManager{
-		dependencies: deps,
-		Clients:      make(map[string]v1.API),
+		dependencies:        deps,
+		Clients:             make(map[string]v1.API),
+		AlertmanagerClients: make(map[string]*amclient.AlertmanagerAPI),
 	}
 
 	hm.initClients(deps)
@@ -49,18 +53,30 @@ func (hm *HandlersManager) initClients(deps HandlersManagerDependencies) {
 			name:      name,
 		}
 
-		client, err := prometheusapi.NewClient(prometheusapi.Config{
-			Address:      backendCfg.URL,
-			RoundTripper: transport,
-		})
-		if err != nil {
-			deps.AppCtx.Logger.Error("Failed to create client", "backend", name, "error", err.Error())
-			continue
+		switch backendCfg.Type {
+		case api.BackendTypeAlertmanager:
+			client, err := newAlertmanagerClient(backendCfg.URL, transport)
+			if err != nil {
+				deps.AppCtx.Logger.Error("Failed to create client", "backend", name, "error", err.Error())
+				continue
+			}
+			hm.AlertmanagerClients[name] = client
+
+		default:
+			client, err := prometheusapi.NewClient(prometheusapi.Config{
+				Address:      backendCfg.URL,
+				RoundTripper: transport,
+			})
+			if err != nil {
+				deps.AppCtx.Logger.Error("Failed to create client", "backend", name, "error", err.Error())
+				continue
+			}
+			hm.Clients[name] = v1.NewAPI(client)
 		}
 
-		hm.Clients[name] = v1.NewAPI(client)
 		deps.AppCtx.Logger.Info("Backend client initialized",
 			"backend", name,
+			"type", backendCfg.Type,
 			"url", backendCfg.URL,
 			"auth_type", backendCfg.Auth.Type,
 			"org_id", backendCfg.OrgID)
@@ -125,6 +141,262 @@ func (hm *HandlersManager) QueryRange(ctx context.Context, backendName string, q
 	return result, nil
 }
 
+func (hm *HandlersManager) Series(ctx context.Context, backendName string, matches []string, startTime, endTime time.Time, limit int, orgID string) ([]model.LabelSet, error) {
+	client, err := hm.GetClient(backendName)
+	if err != nil {
+		return nil, err
+	}
+
+	if orgID != "" {
+		ctx = context.WithValue(ctx, "org_id", orgID)
+	}
+
+	var opts []v1.Option
+	if limit > 0 {
+		opts = append(opts, v1.WithLimit(uint64(limit)))
+	}
+
+	result, warnings, err := client.Series(ctx, matches, startTime, endTime, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching series: %w", err)
+	}
+
+	if len(warnings) > 0 {
+		hm.dependencies.AppCtx.Logger.Warn("Series warnings", "backend", backendName, "warnings", warnings)
+	}
+
+	return result, nil
+}
+
+func (hm *HandlersManager) LabelNames(ctx context.Context, backendName string, matches []string, startTime, endTime time.Time, orgID string) ([]string, error) {
+	client, err := hm.GetClient(backendName)
+	if err != nil {
+		return nil, err
+	}
+
+	if orgID != "" {
+		ctx = context.WithValue(ctx, "org_id", orgID)
+	}
+
+	result, warnings, err := client.LabelNames(ctx, matches, startTime, endTime)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching label names: %w", err)
+	}
+
+	if len(warnings) > 0 {
+		hm.dependencies.AppCtx.Logger.Warn("Label names warnings", "backend", backendName, "warnings", warnings)
+	}
+
+	return result, nil
+}
+
+func (hm *HandlersManager) LabelValues(ctx context.Context, backendName string, label string, matches []string, startTime, endTime time.Time, orgID string) (model.LabelValues, error) {
+	client, err := hm.GetClient(backendName)
+	if err != nil {
+		return nil, err
+	}
+
+	if orgID != "" {
+		ctx = context.WithValue(ctx, "org_id", orgID)
+	}
+
+	result, warnings, err := client.LabelValues(ctx, label, matches, startTime, endTime)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching label values: %w", err)
+	}
+
+	if len(warnings) > 0 {
+		hm.dependencies.AppCtx.Logger.Warn("Label values warnings", "backend", backendName, "warnings", warnings)
+	}
+
+	return result, nil
+}
+
+func (hm *HandlersManager) Metadata(ctx context.Context, backendName string, metric string, orgID string) (map[string][]v1.Metadata, error) {
+	client, err := hm.GetClient(backendName)
+	if err != nil {
+		return nil, err
+	}
+
+	if orgID != "" {
+		ctx = context.WithValue(ctx, "org_id", orgID)
+	}
+
+	result, err := client.Metadata(ctx, metric, "")
+	if err != nil {
+		return nil, fmt.Errorf("error fetching metadata: %w", err)
+	}
+
+	return result, nil
+}
+
+func (hm *HandlersManager) TargetsMetadata(ctx context.Context, backendName string, metric string, orgID string) ([]v1.MetricMetadata, error) {
+	client, err := hm.GetClient(backendName)
+	if err != nil {
+		return nil, err
+	}
+
+	if orgID != "" {
+		ctx = context.WithValue(ctx, "org_id", orgID)
+	}
+
+	result, err := client.TargetsMetadata(ctx, "", metric, "")
+	if err != nil {
+		return nil, fmt.Errorf("error fetching targets metadata: %w", err)
+	}
+
+	return result, nil
+}
+
+func (hm *HandlersManager) Targets(ctx context.Context, backendName string, orgID string) (v1.TargetsResult, error) {
+	client, err := hm.GetClient(backendName)
+	if err != nil {
+		return v1.TargetsResult{}, err
+	}
+
+	if orgID != "" {
+		ctx = context.WithValue(ctx, "org_id", orgID)
+	}
+
+	result, err := client.Targets(ctx)
+	if err != nil {
+		return v1.TargetsResult{}, fmt.Errorf("error fetching targets: %w", err)
+	}
+
+	return result, nil
+}
+
+func (hm *HandlersManager) Rules(ctx context.Context, backendName string, orgID string) (v1.RulesResult, error) {
+	client, err := hm.GetClient(backendName)
+	if err != nil {
+		return v1.RulesResult{}, err
+	}
+
+	if orgID != "" {
+		ctx = context.WithValue(ctx, "org_id", orgID)
+	}
+
+	result, err := client.Rules(ctx)
+	if err != nil {
+		return v1.RulesResult{}, fmt.Errorf("error fetching rules: %w", err)
+	}
+
+	return result, nil
+}
+
+func (hm *HandlersManager) Alerts(ctx context.Context, backendName string, orgID string) (v1.AlertsResult, error) {
+	client, err := hm.GetClient(backendName)
+	if err != nil {
+		return v1.AlertsResult{}, err
+	}
+
+	if orgID != "" {
+		ctx = context.WithValue(ctx, "org_id", orgID)
+	}
+
+	result, err := client.Alerts(ctx)
+	if err != nil {
+		return v1.AlertsResult{}, fmt.Errorf("error fetching alerts: %w", err)
+	}
+
+	return result, nil
+}
+
+func (hm *HandlersManager) TSDB(ctx context.Context, backendName string, limit int, orgID string) (v1.TSDBResult, error) {
+	client, err := hm.GetClient(backendName)
+	if err != nil {
+		return v1.TSDBResult{}, err
+	}
+
+	if orgID != "" {
+		ctx = context.WithValue(ctx, "org_id", orgID)
+	}
+
+	var opts []v1.Option
+	if limit > 0 {
+		opts = append(opts, v1.WithLimit(uint64(limit)))
+	}
+
+	result, err := client.TSDB(ctx, opts...)
+	if err != nil {
+		return v1.TSDBResult{}, fmt.Errorf("error fetching TSDB stats: %w", err)
+	}
+
+	return result, nil
+}
+
+func (hm *HandlersManager) Buildinfo(ctx context.Context, backendName string, orgID string) (v1.BuildinfoResult, error) {
+	client, err := hm.GetClient(backendName)
+	if err != nil {
+		return v1.BuildinfoResult{}, err
+	}
+
+	if orgID != "" {
+		ctx = context.WithValue(ctx, "org_id", orgID)
+	}
+
+	result, err := client.Buildinfo(ctx)
+	if err != nil {
+		return v1.BuildinfoResult{}, fmt.Errorf("error fetching build info: %w", err)
+	}
+
+	return result, nil
+}
+
+func (hm *HandlersManager) Runtimeinfo(ctx context.Context, backendName string, orgID string) (v1.RuntimeinfoResult, error) {
+	client, err := hm.GetClient(backendName)
+	if err != nil {
+		return v1.RuntimeinfoResult{}, err
+	}
+
+	if orgID != "" {
+		ctx = context.WithValue(ctx, "org_id", orgID)
+	}
+
+	result, err := client.Runtimeinfo(ctx)
+	if err != nil {
+		return v1.RuntimeinfoResult{}, fmt.Errorf("error fetching runtime info: %w", err)
+	}
+
+	return result, nil
+}
+
+func (hm *HandlersManager) Flags(ctx context.Context, backendName string, orgID string) (v1.FlagsResult, error) {
+	client, err := hm.GetClient(backendName)
+	if err != nil {
+		return nil, err
+	}
+
+	if orgID != "" {
+		ctx = context.WithValue(ctx, "org_id", orgID)
+	}
+
+	result, err := client.Flags(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching flags: %w", err)
+	}
+
+	return result, nil
+}
+
+func (hm *HandlersManager) QueryExemplars(ctx context.Context, backendName string, query string, startTime, endTime time.Time, orgID string) ([]v1.ExemplarQueryResult, error) {
+	client, err := hm.GetClient(backendName)
+	if err != nil {
+		return nil, err
+	}
+
+	if orgID != "" {
+		ctx = context.WithValue(ctx, "org_id", orgID)
+	}
+
+	result, err := client.QueryExemplars(ctx, query, startTime, endTime)
+	if err != nil {
+		return nil, fmt.Errorf("error querying exemplars: %w", err)
+	}
+
+	return result, nil
+}
+
 type backendTransport struct {
 	transport http.RoundTripper
 	config    *api.BackendConfig
@@ -0,0 +1,82 @@
+package middlewares
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	//
+	"prometheus-mcp/internal/globals"
+
+	//
+	"github.com/mark3labs/mcp-go/server"
+)
+
+type jwtSubjectContextKey struct{}
+
+// jwtValidatedContextKey marks the requests that went through the JWT validation stage
+type jwtValidatedContextKey struct{}
+
+// NewJWTSubjectContextFunc returns an HTTP context function that exposes the subject ('sub' claim)
+// of the JWT forwarded by the validation stage to the tool handlers.
+// The forwarded header is only trusted when JWT validation is enabled and ran for the request,
+// otherwise any client could set it and forge the identity recorded on writes
+func NewJWTSubjectContextFunc(appCtx *globals.ApplicationContext) server.HTTPContextFunc {
+	return func(ctx context.Context, req *http.Request) context.Context {
+		if !appCtx.Config.Middleware.JWT.Enabled {
+			return ctx
+		}
+		if validated, _ := req.Context().Value(jwtValidatedContextKey{}).(bool); !validated {
+			return ctx
+		}
+
+		forwardedHeader := appCtx.Config.Middleware.JWT.Validation.ForwardedHeader
+		if forwardedHeader == "" {
+			return ctx
+		}
+
+		subject := jwtSubject(req.Header.Get(forwardedHeader))
+		if subject == "" {
+			return ctx
+		}
+
+		return context.WithValue(ctx, jwtSubjectContextKey{}, subject)
+	}
+}
+
+// JWTSubjectFromContext returns the subject of the authenticated JWT, or empty when there is none
+func JWTSubjectFromContext(ctx context.Context) string {
+	subject, _ := ctx.Value(jwtSubjectContextKey{}).(string)
+	return subject
+}
+
+// jwtSubject extracts the 'sub' claim from a forwarded JWT.
+// Upstream proxies may forward the whole token or only its base64-encoded payload (e.g., Istio)
+func jwtSubject(forwarded string) string {
+	if forwarded == "" {
+		return ""
+	}
+
+	payload := forwarded
+	if parts := strings.Split(forwarded, "."); len(parts) == 3 {
+		payload = parts[1]
+	}
+
+	payloadBytes, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(payload, "="))
+	if err != nil {
+		payloadBytes, err = base64.StdEncoding.DecodeString(payload)
+		if err != nil {
+			return ""
+		}
+	}
+
+	claims := map[string]any{}
+	if err := json.Unmarshal(payloadBytes, &claims); err != nil {
+		return ""
+	}
+
+	subject, _ := claims["sub"].(string)
+	return subject
+}
@@ -0,0 +1,99 @@
+package middlewares
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"prometheus-mcp/api"
+	"prometheus-mcp/internal/globals"
+)
+
+func TestJWTSubject(t *testing.T) {
+	payload := `{"sub":"jane.doe","email":"jane@example.com"}`
+	encodedPayload := base64.RawURLEncoding.EncodeToString([]byte(payload))
+
+	tests := []struct {
+		name      string
+		forwarded string
+		want      string
+	}{
+		{
+			name:      "full token",
+			forwarded: "eyJhbGciOiJSUzI1NiJ9." + encodedPayload + ".signature",
+			want:      "jane.doe",
+		},
+		{
+			name:      "payload only",
+			forwarded: base64.StdEncoding.EncodeToString([]byte(payload)),
+			want:      "jane.doe",
+		},
+		{
+			name:      "empty header",
+			forwarded: "",
+			want:      "",
+		},
+		{
+			name:      "garbage",
+			forwarded: "not-a-jwt",
+			want:      "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := jwtSubject(tt.forwarded); got != tt.want {
+				t.Errorf("got subject = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJWTSubjectContextFunc(t *testing.T) {
+	spoofed := "eyJhbGciOiJub25lIn0." + base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"mallory"}`)) + "."
+
+	tests := []struct {
+		name         string
+		enabled      bool
+		throughStage bool
+		wantSubject  string
+	}{
+		{name: "jwt disabled ignores the spoofed header", enabled: false, throughStage: true, wantSubject: ""},
+		{name: "validation stage skipped ignores the header", enabled: true, throughStage: false, wantSubject: ""},
+		{name: "validated request exposes the subject", enabled: true, throughStage: true, wantSubject: "mallory"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			appCtx := &globals.ApplicationContext{Config: &api.Configuration{}}
+			appCtx.Config.Middleware.JWT.Enabled = tt.enabled
+			appCtx.Config.Middleware.JWT.Validation.Strategy = "delegated"
+			appCtx.Config.Middleware.JWT.Validation.ForwardedHeader = "X-Validated-Jwt"
+
+			contextFunc := NewJWTSubjectContextFunc(appCtx)
+			var got string
+			handler := http.HandlerFunc(func(_ http.ResponseWriter, req *http.Request) {
+				got = JWTSubjectFromContext(contextFunc(context.Background(), req))
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+			req.Header.Set("X-Validated-Jwt", spoofed)
+
+			if tt.throughStage {
+				mw, err := NewJWTValidationMiddleware(JWTValidationMiddlewareDependencies{AppCtx: appCtx})
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				mw.Middleware(handler).ServeHTTP(httptest.NewRecorder(), req)
+			} else {
+				handler.ServeHTTP(httptest.NewRecorder(), req)
+			}
+
+			if got != tt.wantSubject {
+				t.Errorf("subject = %q, want %q", got, tt.wantSubject)
+			}
+		})
+	}
+}
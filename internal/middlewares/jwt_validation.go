@@ -1,6 +1,7 @@
 package middlewares
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -141,6 +142,9 @@ func (mw *JWTValidationMiddleware) Middleware(next http.Handler) http.Handler {
 			// When the token is already validated, do nothing.
 		}
 
+		// Let later stages trust the forwarded JWT
+		req = req.WithContext(context.WithValue(req.Context(), jwtValidatedContextKey{}, true))
+
 	nextStage:
 		next.ServeHTTP(rw, req)
 	})
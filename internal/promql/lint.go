@@ -0,0 +1,369 @@
+package promql
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql/parser"
+)
+
+const (
+	// HighCardinalitySeries is the series count from which a metric is considered high-cardinality
+	HighCardinalitySeries = 10000
+
+	suggestedRateRange = 5 * time.Minute
+)
+
+// Metric types, as returned by the metadata endpoint
+const (
+	metricTypeCounter   = "counter"
+	metricTypeGauge     = "gauge"
+	metricTypeHistogram = "histogram"
+	metricTypeSummary   = "summary"
+)
+
+// LintContext represents the backend knowledge used to lint an expression.
+// Both maps are optional, rules depending on missing data are skipped
+type LintContext struct {
+	// MetricTypes maps metric names (as exposed by metadata) to their type
+	MetricTypes map[string]string
+
+	// SeriesCount maps metric names to their number of series
+	SeriesCount map[string]int
+}
+
+// Warning represents a semantic issue found in an expression
+type Warning struct {
+	Rule       string `json:"rule"`
+	Message    string `json:"message"`
+	Expression string `json:"expression"`
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+var (
+	// counterFunctions only make sense over counters
+	counterFunctions = map[string]string{
+		"rate":     "deriv",
+		"irate":    "deriv",
+		"increase": "delta",
+		"resets":   "changes",
+	}
+
+	// gaugeFunctions only make sense over gauges
+	gaugeFunctions = map[string]string{
+		"deriv": "rate",
+		"delta": "increase",
+	}
+
+	// rawSelectorSafeFunctions do not care about the value of the series they get
+	rawSelectorSafeFunctions = map[string]struct{}{
+		"absent":        {},
+		"timestamp":     {},
+		"label_replace": {},
+		"label_join":    {},
+	}
+
+	// rawSelectorSafeAggregations do not care about the value of the series they get
+	rawSelectorSafeAggregations = map[string]struct{}{
+		"count":        {},
+		"group":        {},
+		"count_values": {},
+	}
+)
+
+// Lint checks an expression for semantic mistakes: counter functions over gauges (and vice versa),
+// raw counters without rate, histogram_quantile misuse and sums over high-cardinality metrics
+func Lint(expr parser.Expr, lintCtx LintContext) []Warning {
+	warnings := []Warning{}
+
+	parser.Inspect(expr, func(node parser.Node, path []parser.Node) error {
+		switch n := node.(type) {
+		case *parser.Call:
+			warnings = append(warnings, lintFunctionType(n, lintCtx)...)
+			if n.Func.Name == "histogram_quantile" {
+				warnings = append(warnings, lintHistogramQuantile(n, lintCtx)...)
+			}
+
+		case *parser.VectorSelector:
+			if warning, ok := lintRawCounter(n, path, lintCtx); ok {
+				warnings = append(warnings, warning)
+			}
+
+		case *parser.AggregateExpr:
+			if warning, ok := lintUngroupedSum(n, lintCtx); ok {
+				warnings = append(warnings, warning)
+			}
+		}
+		return nil
+	})
+
+	return warnings
+}
+
+// lintFunctionType warns about counter functions over gauges and gauge functions over counters
+func lintFunctionType(call *parser.Call, lintCtx LintContext) []Warning {
+	var warnings []Warning
+
+	replacement, isCounterFunction := counterFunctions[call.Func.Name]
+	if !isCounterFunction {
+		replacement = gaugeFunctions[call.Func.Name]
+	}
+	if replacement == "" || len(call.Args) == 0 {
+		return nil
+	}
+
+	for _, selector := range vectorSelectors(call.Args[0]) {
+		name := selectorMetricName(selector)
+		metricType := lintCtx.metricType(name)
+
+		wrongType := (isCounterFunction && metricType == metricTypeGauge) ||
+			(!isCounterFunction && metricType == metricTypeCounter)
+		if !wrongType {
+			continue
+		}
+
+		rewritten := *call
+		rewritten.Func = parser.Functions[replacement]
+		warnings = append(warnings, Warning{
+			Rule:       fmt.Sprintf("%s-on-%s", call.Func.Name, metricType),
+			Message:    fmt.Sprintf("%s() is applied to %q, which is a %s", call.Func.Name, name, metricType),
+			Expression: call.String(),
+			Suggestion: rewritten.String(),
+		})
+	}
+
+	return warnings
+}
+
+// lintRawCounter warns about counters used without a range function, as their absolute value is meaningless
+func lintRawCounter(selector *parser.VectorSelector, path []parser.Node, lintCtx LintContext) (Warning, bool) {
+	name := selectorMetricName(selector)
+	if lintCtx.metricType(name) != metricTypeCounter {
+		return Warning{}, false
+	}
+
+	for _, ancestor := range path {
+		switch a := ancestor.(type) {
+		case *parser.MatrixSelector, *parser.SubqueryExpr:
+			return Warning{}, false
+		case *parser.Call:
+			if _, ok := rawSelectorSafeFunctions[a.Func.Name]; ok {
+				return Warning{}, false
+			}
+		case *parser.AggregateExpr:
+			if _, ok := rawSelectorSafeAggregations[a.Op.String()]; ok {
+				return Warning{}, false
+			}
+		}
+	}
+
+	matrix := &parser.MatrixSelector{VectorSelector: selector, Range: suggestedRateRange}
+	return Warning{
+		Rule:       "raw-counter",
+		Message:    fmt.Sprintf("%q is a counter used without rate() or increase(), its absolute value only grows and resets on restarts", name),
+		Expression: selector.String(),
+		Suggestion: fmt.Sprintf("rate(%s)", matrix.String()),
+	}, true
+}
+
+// lintHistogramQuantile warns about histogram_quantile over non-bucket series or aggregations dropping the "le" label
+func lintHistogramQuantile(call *parser.Call, lintCtx LintContext) []Warning {
+	if len(call.Args) < 2 {
+		return nil
+	}
+
+	var warnings []Warning
+
+	for _, selector := range vectorSelectors(call.Args[1]) {
+		name := selectorMetricName(selector)
+		if name == "" || isBucketSeries(name) {
+			continue
+		}
+
+		// Native histograms are exposed without suffix, but so is the metadata of classic ones
+		message := fmt.Sprintf("histogram_quantile() is applied to %q, which is not a classic histogram _bucket series", name)
+		if lintCtx.metricType(name) == metricTypeHistogram {
+			message = fmt.Sprintf("histogram_quantile() is applied to %q: unless it is exposed as a native histogram, use its _bucket series", name)
+		}
+
+		// Recorded series have no bucket counterpart to point at
+		suggestion := ""
+		if !strings.Contains(name, ":") {
+			suggestion = renameQuantileSelectors(call, name, name+"_bucket")
+		}
+
+		warnings = append(warnings, Warning{
+			Rule:       "histogram-quantile-non-bucket",
+			Message:    message,
+			Expression: call.String(),
+			Suggestion: suggestion,
+		})
+	}
+
+	parser.Inspect(call.Args[1], func(node parser.Node, _ []parser.Node) error {
+		aggregation, ok := node.(*parser.AggregateExpr)
+		if !ok || !hasBucketSelector(aggregation.Expr) {
+			return nil
+		}
+
+		keepsLe := containsString(aggregation.Grouping, "le") != aggregation.Without
+		if keepsLe {
+			return nil
+		}
+
+		rewritten := *aggregation
+		if aggregation.Without {
+			rewritten.Grouping = removeString(aggregation.Grouping, "le")
+		} else {
+			rewritten.Grouping = append(append([]string{}, aggregation.Grouping...), "le")
+		}
+
+		warnings = append(warnings, Warning{
+			Rule:       "histogram-quantile-missing-le",
+			Message:    fmt.Sprintf("%s() inside histogram_quantile() drops the \"le\" label, which is required to compute quantiles over classic histograms", aggregation.Op.String()),
+			Expression: aggregation.String(),
+			Suggestion: rewritten.String(),
+		})
+		return nil
+	})
+
+	return warnings
+}
+
+// lintUngroupedSum warns about sums collapsing every series of a high-cardinality metric into a single one
+func lintUngroupedSum(aggregation *parser.AggregateExpr, lintCtx LintContext) (Warning, bool) {
+	if aggregation.Op.String() != "sum" || len(aggregation.Grouping) > 0 || aggregation.Without {
+		return Warning{}, false
+	}
+
+	for _, selector := range vectorSelectors(aggregation.Expr) {
+		name := selectorMetricName(selector)
+		seriesCount := lintCtx.SeriesCount[name]
+		if seriesCount < HighCardinalitySeries {
+			continue
+		}
+
+		return Warning{
+			Rule:       "sum-without-by-high-cardinality",
+			Message:    fmt.Sprintf("sum() without by() aggregates all %d series of %q into a single one, which is expensive and hides where the value comes from", seriesCount, name),
+			Expression: aggregation.String(),
+			Suggestion: fmt.Sprintf("sum by (job) (%s)", aggregation.Expr.String()),
+		}, true
+	}
+
+	return Warning{}, false
+}
+
+// metricType returns the type of a series name, resolving the suffixes added to counters, histograms and summaries
+func (lintCtx LintContext) metricType(name string) string {
+	if name == "" || lintCtx.MetricTypes == nil {
+		return ""
+	}
+
+	if metricType, ok := lintCtx.MetricTypes[name]; ok {
+		return metricType
+	}
+
+	// OpenMetrics exposes counter metadata without the _total suffix
+	if base, ok := strings.CutSuffix(name, "_total"); ok && lintCtx.MetricTypes[base] == metricTypeCounter {
+		return metricTypeCounter
+	}
+
+	// Classic histograms and summaries are composed of counters
+	for _, suffix := range []string{"_bucket", "_count", "_sum"} {
+		base, ok := strings.CutSuffix(name, suffix)
+		if !ok {
+			continue
+		}
+		switch lintCtx.MetricTypes[base] {
+		case metricTypeHistogram, metricTypeSummary:
+			return metricTypeCounter
+		}
+	}
+
+	return ""
+}
+
+// vectorSelectors returns every vector selector under a node
+func vectorSelectors(node parser.Node) []*parser.VectorSelector {
+	var selectors []*parser.VectorSelector
+	parser.Inspect(node, func(n parser.Node, _ []parser.Node) error {
+		if selector, ok := n.(*parser.VectorSelector); ok {
+			selectors = append(selectors, selector)
+		}
+		return nil
+	})
+	return selectors
+}
+
+func hasBucketSelector(node parser.Node) bool {
+	for _, selector := range vectorSelectors(node) {
+		if isBucketSeries(selectorMetricName(selector)) {
+			return true
+		}
+	}
+	return false
+}
+
+// isBucketSeries reports whether a series holds classic histogram buckets, also when
+// it is recorded under a level:metric:operations name like job:latency_seconds_bucket:rate5m
+func isBucketSeries(name string) bool {
+	if strings.HasSuffix(name, "_bucket") {
+		return true
+	}
+	if i := strings.LastIndex(name, ":"); i > 0 && strings.Contains(name[:i], ":") {
+		return strings.HasSuffix(name[:i], "_bucket")
+	}
+	return false
+}
+
+// renameQuantileSelectors prints a copy of a histogram_quantile call whose selectors named from, in its
+// histogram argument only, are renamed to. The call itself is left untouched
+func renameQuantileSelectors(call *parser.Call, from, to string) string {
+	// Printing and parsing again is the only deep copy the AST offers
+	expr, err := parser.ParseExpr(call.String())
+	if err != nil {
+		return ""
+	}
+	clone, ok := expr.(*parser.Call)
+	if !ok || len(clone.Args) < 2 {
+		return ""
+	}
+
+	for _, selector := range vectorSelectors(clone.Args[1]) {
+		if selectorMetricName(selector) != from {
+			continue
+		}
+		if selector.Name != "" {
+			selector.Name = to
+		}
+		for i, matcher := range selector.LabelMatchers {
+			if matcher.Name == model.MetricNameLabel && matcher.Type == labels.MatchEqual {
+				selector.LabelMatchers[i] = labels.MustNewMatcher(labels.MatchEqual, model.MetricNameLabel, to)
+			}
+		}
+	}
+	return clone.String()
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func removeString(values []string, value string) []string {
+	result := []string{}
+	for _, v := range values {
+		if v != value {
+			result = append(result, v)
+		}
+	}
+	return result
+}
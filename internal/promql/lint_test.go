@@ -0,0 +1,122 @@
+package promql
+
+import (
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+	lintCtx := LintContext{
+		MetricTypes: map[string]string{
+			"http_requests_total":           "counter",
+			"node_memory_available_bytes":   "gauge",
+			"http_request_duration_seconds": "histogram",
+		},
+		SeriesCount: map[string]int{
+			"http_requests_total": 50000,
+		},
+	}
+
+	tests := []struct {
+		name           string
+		query          string
+		wantRules      []string
+		wantSuggestion string
+	}{
+		{
+			name:           "rate on gauge",
+			query:          `rate(node_memory_available_bytes[5m])`,
+			wantRules:      []string{"rate-on-gauge"},
+			wantSuggestion: `deriv(node_memory_available_bytes[5m])`,
+		},
+		{
+			name:           "delta on counter",
+			query:          `sum by (job) (delta(http_requests_total[5m]))`,
+			wantRules:      []string{"delta-on-counter"},
+			wantSuggestion: `increase(http_requests_total[5m])`,
+		},
+		{
+			name:           "raw counter",
+			query:          `http_requests_total{job="api"} > 100`,
+			wantRules:      []string{"raw-counter"},
+			wantSuggestion: `rate(http_requests_total{job="api"}[5m])`,
+		},
+		{
+			name:      "counted counter is fine",
+			query:     `count(http_requests_total)`,
+			wantRules: []string{},
+		},
+		{
+			name:           "histogram_quantile over non bucket series",
+			query:          `histogram_quantile(0.9, sum by (le) (rate(http_request_duration_seconds[5m])))`,
+			wantRules:      []string{"histogram-quantile-non-bucket"},
+			wantSuggestion: `histogram_quantile(0.9, sum by (le) (rate(http_request_duration_seconds_bucket[5m])))`,
+		},
+		{
+			name:           "histogram_quantile suggestion only renames the histogram selector",
+			query:          `histogram_quantile(scalar(latency_target), rate(latency{job="latency"}[5m]))`,
+			wantRules:      []string{"histogram-quantile-non-bucket"},
+			wantSuggestion: `histogram_quantile(scalar(latency_target), rate(latency_bucket{job="latency"}[5m]))`,
+		},
+		{
+			name:           "histogram_quantile suggestion renames __name__ matchers",
+			query:          `histogram_quantile(0.9, sum by (le) (rate({__name__="latency_seconds"}[5m])))`,
+			wantRules:      []string{"histogram-quantile-non-bucket"},
+			wantSuggestion: `histogram_quantile(0.9, sum by (le) (rate({__name__="latency_seconds_bucket"}[5m])))`,
+		},
+		{
+			name:      "histogram_quantile over recorded buckets",
+			query:     `histogram_quantile(0.9, sum by (le) (job:http_request_duration_seconds_bucket:rate5m))`,
+			wantRules: []string{},
+		},
+		{
+			name:      "histogram_quantile over recorded non bucket series",
+			query:     `histogram_quantile(0.9, job:http_request_duration_seconds:mean5m)`,
+			wantRules: []string{"histogram-quantile-non-bucket"},
+		},
+		{
+			name:           "histogram_quantile over recorded buckets without le",
+			query:          `histogram_quantile(0.9, sum by (job) (job:http_request_duration_seconds_bucket:rate5m))`,
+			wantRules:      []string{"histogram-quantile-missing-le"},
+			wantSuggestion: `sum by (job, le) (job:http_request_duration_seconds_bucket:rate5m)`,
+		},
+		{
+			name:           "histogram_quantile without le",
+			query:          `histogram_quantile(0.9, sum by (job) (rate(http_request_duration_seconds_bucket[5m])))`,
+			wantRules:      []string{"histogram-quantile-missing-le"},
+			wantSuggestion: `sum by (job, le) (rate(http_request_duration_seconds_bucket[5m]))`,
+		},
+		{
+			name:      "correct histogram_quantile",
+			query:     `histogram_quantile(0.9, sum by (job, le) (rate(http_request_duration_seconds_bucket[5m])))`,
+			wantRules: []string{},
+		},
+		{
+			name:           "sum without by on high cardinality metric",
+			query:          `sum(rate(http_requests_total[5m]))`,
+			wantRules:      []string{"sum-without-by-high-cardinality"},
+			wantSuggestion: `sum by (job) (rate(http_requests_total[5m]))`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := Parse(tt.query)
+			if err != nil {
+				t.Fatalf("unexpected parse error: %v", err)
+			}
+
+			warnings := Lint(expr, lintCtx)
+			if len(warnings) != len(tt.wantRules) {
+				t.Fatalf("got %d warnings (%+v), want %d", len(warnings), warnings, len(tt.wantRules))
+			}
+			for i, warning := range warnings {
+				if warning.Rule != tt.wantRules[i] {
+					t.Errorf("warning %d rule = %q, want %q", i, warning.Rule, tt.wantRules[i])
+				}
+			}
+			if tt.wantSuggestion != "" && warnings[0].Suggestion != tt.wantSuggestion {
+				t.Errorf("suggestion = %q, want %q", warnings[0].Suggestion, tt.wantSuggestion)
+			}
+		})
+	}
+}
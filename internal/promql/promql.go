@@ -0,0 +1,250 @@
+package promql
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql/parser"
+	"github.com/prometheus/prometheus/promql/parser/posrange"
+)
+
+// Selector represents a series selector found in an expression
+type Selector struct {
+	Metric   string   `json:"metric"`
+	Matchers []string `json:"matchers"`
+	Range    string   `json:"range"`
+	Offset   string   `json:"offset"`
+}
+
+// Aggregation represents an aggregation operation found in an expression
+type Aggregation struct {
+	Operator string   `json:"operator"`
+	Grouping string   `json:"grouping"` // "by", "without" or empty
+	Labels   []string `json:"labels"`
+}
+
+// Summary represents a structured description of a PromQL expression
+type Summary struct {
+	ResultType   string        `json:"result_type"`
+	Formatted    string        `json:"formatted"`
+	Metrics      []string      `json:"metrics"`
+	Selectors    []Selector    `json:"selectors"`
+	Functions    []string      `json:"functions"`
+	Aggregations []Aggregation `json:"aggregations"`
+	BinaryOps    []string      `json:"binary_operators"`
+	Ranges       []string      `json:"ranges"`
+	Subqueries   []string      `json:"subqueries"`
+}
+
+// ErrorPosition represents the location of a single syntax error
+type ErrorPosition struct {
+	Message string `json:"message"`
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+	Start   int    `json:"start"`
+	End     int    `json:"end"`
+	Snippet string `json:"snippet"`
+}
+
+// ParseError is returned when an expression is not valid PromQL
+type ParseError struct {
+	Positions []ErrorPosition
+}
+
+func (e *ParseError) Error() string {
+	if len(e.Positions) == 0 {
+		return "parse error"
+	}
+	first := e.Positions[0]
+	return fmt.Sprintf("parse error at line %d, column %d: %s", first.Line, first.Column, first.Message)
+}
+
+// Parse parses an expression locally, without touching any backend.
+// Syntax errors are returned as *ParseError, carrying their positions
+func Parse(query string) (parser.Expr, error) {
+	expr, err := parser.ParseExpr(query)
+	if err == nil {
+		return expr, nil
+	}
+
+	var parseErrs parser.ParseErrors
+	if errors.As(err, &parseErrs) {
+		result := &ParseError{}
+		for _, parseErr := range parseErrs {
+			result.Positions = append(result.Positions, errorPosition(query, parseErr.PositionRange, parseErr.Err))
+		}
+		return nil, result
+	}
+
+	var parseErr *parser.ParseErr
+	if errors.As(err, &parseErr) {
+		return nil, &ParseError{
+			Positions: []ErrorPosition{errorPosition(query, parseErr.PositionRange, parseErr.Err)},
+		}
+	}
+
+	return nil, &ParseError{
+		Positions: []ErrorPosition{{Message: err.Error(), Line: 1, Column: 1}},
+	}
+}
+
+// Summarize walks the expression and collects its selectors, functions, aggregations and ranges
+func Summarize(expr parser.Expr) Summary {
+	summary := Summary{
+		ResultType:   string(expr.Type()),
+		Formatted:    parser.Prettify(expr),
+		Metrics:      []string{},
+		Selectors:    []Selector{},
+		Functions:    []string{},
+		Aggregations: []Aggregation{},
+		BinaryOps:    []string{},
+		Ranges:       []string{},
+		Subqueries:   []string{},
+	}
+
+	metrics := map[string]struct{}{}
+	functions := map[string]struct{}{}
+	binaryOps := map[string]struct{}{}
+
+	parser.Inspect(expr, func(node parser.Node, path []parser.Node) error {
+		switch n := node.(type) {
+		case *parser.VectorSelector:
+			selector := Selector{
+				Metric:   selectorMetricName(n),
+				Matchers: []string{},
+			}
+			for _, matcher := range n.LabelMatchers {
+				if matcher.Name == model.MetricNameLabel && matcher.Type == labels.MatchEqual {
+					continue
+				}
+				selector.Matchers = append(selector.Matchers, matcher.String())
+			}
+			if n.OriginalOffset != 0 {
+				selector.Offset = model.Duration(n.OriginalOffset).String()
+			}
+
+			// The range lives in the parent matrix selector
+			if len(path) > 0 {
+				if matrix, ok := path[len(path)-1].(*parser.MatrixSelector); ok {
+					selector.Range = model.Duration(matrix.Range).String()
+				}
+			}
+
+			if selector.Metric != "" {
+				metrics[selector.Metric] = struct{}{}
+			}
+			summary.Selectors = append(summary.Selectors, selector)
+
+		case *parser.MatrixSelector:
+			summary.Ranges = append(summary.Ranges, model.Duration(n.Range).String())
+
+		case *parser.SubqueryExpr:
+			subquery := model.Duration(n.Range).String() + ":"
+			if n.Step != 0 {
+				subquery += model.Duration(n.Step).String()
+			}
+			summary.Subqueries = append(summary.Subqueries, subquery)
+
+		case *parser.Call:
+			functions[n.Func.Name] = struct{}{}
+
+		case *parser.AggregateExpr:
+			aggregation := Aggregation{
+				Operator: n.Op.String(),
+				Labels:   []string{},
+			}
+			if len(n.Grouping) > 0 || n.Without {
+				aggregation.Grouping = "by"
+				if n.Without {
+					aggregation.Grouping = "without"
+				}
+				aggregation.Labels = append(aggregation.Labels, n.Grouping...)
+			}
+			summary.Aggregations = append(summary.Aggregations, aggregation)
+
+		case *parser.BinaryExpr:
+			binaryOps[n.Op.String()] = struct{}{}
+		}
+		return nil
+	})
+
+	summary.Metrics = sortedKeys(metrics)
+	summary.Functions = sortedKeys(functions)
+	summary.BinaryOps = sortedKeys(binaryOps)
+
+	return summary
+}
+
+// selectorMetricName returns the metric name of a selector, also when written as {__name__="..."}
+func selectorMetricName(selector *parser.VectorSelector) string {
+	if selector.Name != "" {
+		return selector.Name
+	}
+	for _, matcher := range selector.LabelMatchers {
+		if matcher.Name == model.MetricNameLabel && matcher.Type == labels.MatchEqual {
+			return matcher.Value
+		}
+	}
+	return ""
+}
+
+// errorPosition converts a parser position range into line/column coordinates with a pointer snippet
+func errorPosition(query string, posRange posrange.PositionRange, err error) ErrorPosition {
+	start := int(posRange.Start)
+	end := int(posRange.End)
+	if start < 0 || start > len(query) {
+		start = len(query)
+	}
+	if end < start || end > len(query) {
+		end = start
+	}
+
+	line := 1
+	lineStart := 0
+	for i, c := range query[:start] {
+		if c == '\n' {
+			line++
+			lineStart = i + 1
+		}
+	}
+
+	lineEnd := strings.IndexByte(query[lineStart:], '\n')
+	if lineEnd < 0 {
+		lineEnd = len(query)
+	} else {
+		lineEnd += lineStart
+	}
+
+	column := start - lineStart + 1
+	pointerWidth := end - start
+	if pointerWidth < 1 || start+pointerWidth > lineEnd {
+		pointerWidth = 1
+	}
+
+	message := ""
+	if err != nil {
+		message = err.Error()
+	}
+
+	return ErrorPosition{
+		Message: message,
+		Line:    line,
+		Column:  column,
+		Start:   start,
+		End:     end,
+		Snippet: query[lineStart:lineEnd] + "\n" + strings.Repeat(" ", column-1) + strings.Repeat("^", pointerWidth),
+	}
+}
+
+func sortedKeys(set map[string]struct{}) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
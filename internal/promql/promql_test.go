@@ -0,0 +1,60 @@
+package promql
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSummarize(t *testing.T) {
+	expr, err := Parse(`histogram_quantile(0.99, sum by (le, job) (rate(http_request_duration_seconds_bucket{job=~"api.*"}[5m] offset 1h))) > on() group_left max_over_time(up[1h:1m])`)
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	summary := Summarize(expr)
+
+	if summary.ResultType != "vector" {
+		t.Errorf("result type = %q, want %q", summary.ResultType, "vector")
+	}
+	if want := []string{"http_request_duration_seconds_bucket", "up"}; !reflect.DeepEqual(summary.Metrics, want) {
+		t.Errorf("metrics = %v, want %v", summary.Metrics, want)
+	}
+	if want := []string{"histogram_quantile", "max_over_time", "rate"}; !reflect.DeepEqual(summary.Functions, want) {
+		t.Errorf("functions = %v, want %v", summary.Functions, want)
+	}
+	if len(summary.Aggregations) != 1 || summary.Aggregations[0].Operator != "sum" || summary.Aggregations[0].Grouping != "by" {
+		t.Errorf("aggregations = %+v, want a single sum by", summary.Aggregations)
+	}
+	if want := []string{"5m"}; !reflect.DeepEqual(summary.Ranges, want) {
+		t.Errorf("ranges = %v, want %v", summary.Ranges, want)
+	}
+	if want := []string{"1h:1m"}; !reflect.DeepEqual(summary.Subqueries, want) {
+		t.Errorf("subqueries = %v, want %v", summary.Subqueries, want)
+	}
+
+	selector := summary.Selectors[0]
+	if selector.Range != "5m" || selector.Offset != "1h" || !reflect.DeepEqual(selector.Matchers, []string{`job=~"api.*"`}) {
+		t.Errorf("selector = %+v", selector)
+	}
+}
+
+func TestParseError(t *testing.T) {
+	_, err := Parse("sum(rate(http_requests_total[5m])\n  by (job)")
+
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("expected *ParseError, got %v", err)
+	}
+	if len(parseErr.Positions) == 0 {
+		t.Fatal("expected at least one error position")
+	}
+
+	position := parseErr.Positions[0]
+	if position.Line != 2 {
+		t.Errorf("line = %d, want 2", position.Line)
+	}
+	if position.Message == "" || position.Snippet == "" {
+		t.Errorf("expected message and snippet, got %+v", position)
+	}
+}
@@ -0,0 +1,134 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"prometheus-mcp/internal/handlers"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func (tm *ToolsManager) HandleToolAlertmanagerAlerts(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		Backend   string   `json:"backend,omitempty"`
+		Matchers  []string `json:"matchers,omitempty"`
+		Receiver  string   `json:"receiver,omitempty"`
+		Active    *bool    `json:"active,omitempty"`
+		Silenced  *bool    `json:"silenced,omitempty"`
+		Inhibited *bool    `json:"inhibited,omitempty"`
+		OrgID     string   `json:"org_id,omitempty"`
+		Limit     int      `json:"limit,omitempty"`
+		Offset    int      `json:"offset,omitempty"`
+	}
+
+	argsBytes, err := json.Marshal(request.Params.Arguments)
+	if err != nil {
+		return mcp.NewToolResultError("failed to marshal arguments: " + err.Error()), nil
+	}
+	if err = json.Unmarshal(argsBytes, &args); err != nil {
+		return mcp.NewToolResultError("failed to parse arguments: " + err.Error()), nil
+	}
+
+	encoder, err := tm.resultEncoder(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	backendName, err := tm.resolveAlertmanagerBackend(args.Backend)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	tm.warnIfOrgIDIgnored(backendName, args.OrgID)
+
+	if args.Limit <= 0 {
+		args.Limit = defaultAlertsLimit
+	}
+	if args.Offset < 0 {
+		args.Offset = 0
+	}
+
+	alerts, err := tm.dependencies.HandlersManager.AlertmanagerAlerts(ctx, backendName, handlers.AlertsFilter{
+		Matchers:  args.Matchers,
+		Receiver:  args.Receiver,
+		Active:    args.Active,
+		Silenced:  args.Silenced,
+		Inhibited: args.Inhibited,
+	}, args.OrgID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to fetch alerts from backend %q: %s", backendName, err.Error())), nil
+	}
+
+	// Oldest alerts first, so long-standing problems are visible on the first page
+	sort.SliceStable(alerts, func(i, j int) bool {
+		return formatDateTime(alerts[i].StartsAt) < formatDateTime(alerts[j].StartsAt)
+	})
+
+	entries := make([]map[string]interface{}, 0, len(alerts))
+	stateCount := map[string]int{}
+
+	for _, alert := range alerts {
+		state := ""
+		var silencedBy, inhibitedBy []string
+		if alert.Status != nil {
+			if alert.Status.State != nil {
+				state = *alert.Status.State
+			}
+			silencedBy = alert.Status.SilencedBy
+			inhibitedBy = alert.Status.InhibitedBy
+		}
+
+		receivers := make([]string, 0, len(alert.Receivers))
+		for _, receiver := range alert.Receivers {
+			if receiver != nil && receiver.Name != nil {
+				receivers = append(receivers, *receiver.Name)
+			}
+		}
+
+		stateCount[state]++
+		entries = append(entries, map[string]interface{}{
+			"name":         alert.Labels["alertname"],
+			"state":        state,
+			"silenced":     len(silencedBy) > 0,
+			"inhibited":    len(inhibitedBy) > 0,
+			"silenced_by":  silencedBy,
+			"inhibited_by": inhibitedBy,
+			"starts_at":    formatDateTime(alert.StartsAt),
+			"ends_at":      formatDateTime(alert.EndsAt),
+			"receivers":    receivers,
+			"labels":       alert.Labels,
+			"annotations":  alert.Annotations,
+		})
+	}
+
+	totalAlerts := len(entries)
+	start, end := paginationBounds(totalAlerts, args.Offset, args.Limit)
+
+	encodedResult, err := encoder.Encode(map[string]interface{}{
+		"total_alerts": totalAlerts,
+		"by_state":     stateCount,
+		"returned":     end - start,
+		"offset":       args.Offset,
+		"limit":        args.Limit,
+		"has_more":     end < totalAlerts,
+		"alerts":       entries[start:end],
+	})
+	if err != nil {
+		return mcp.NewToolResultError("failed to marshal result: " + err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Alertmanager Alerts [%s]:\n\n%s", backendName, encodedResult)), nil
+}
+
+// formatDateTime renders an optional Alertmanager timestamp as RFC3339
+func formatDateTime(dt *strfmt.DateTime) string {
+	if dt == nil {
+		return ""
+	}
+	return time.Time(*dt).Format(time.RFC3339)
+}
@@ -0,0 +1,250 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"prometheus-mcp/api"
+	"prometheus-mcp/internal/middlewares"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/prometheus/alertmanager/pkg/labels"
+	"github.com/prometheus/common/model"
+)
+
+const (
+	defaultSilenceMaxDuration = 24 * time.Hour
+
+	// maxSilenceStartDelay bounds how far ahead a silence can be scheduled, far starts are usually mistakes
+	maxSilenceStartDelay = 7 * 24 * time.Hour
+)
+
+func (tm *ToolsManager) HandleToolAlertmanagerCreateSilence(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		Backend  string   `json:"backend,omitempty"`
+		Matchers []string `json:"matchers"`
+		StartsAt string   `json:"starts_at,omitempty"`
+		EndsAt   string   `json:"ends_at,omitempty"`
+		Timezone string   `json:"timezone,omitempty"`
+		Duration string   `json:"duration,omitempty"`
+		Comment  string   `json:"comment"`
+		OrgID    string   `json:"org_id,omitempty"`
+	}
+
+	argsBytes, err := json.Marshal(request.Params.Arguments)
+	if err != nil {
+		return mcp.NewToolResultError("failed to marshal arguments: " + err.Error()), nil
+	}
+	if err = json.Unmarshal(argsBytes, &args); err != nil {
+		return mcp.NewToolResultError("failed to parse arguments: " + err.Error()), nil
+	}
+
+	encoder, err := tm.resultEncoder(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	backendName, err := tm.resolveAlertmanagerBackend(args.Backend)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	tm.warnIfOrgIDIgnored(backendName, args.OrgID)
+
+	silencesCfg, err := tm.silenceWriteConfig(backendName)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	createdBy, err := tm.silenceAuthor(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if strings.TrimSpace(args.Comment) == "" {
+		return mcp.NewToolResultError("comment parameter is required"), nil
+	}
+
+	matchers, err := parseSilenceMatchers(args.Matchers)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	loc, err := loadTimezone(args.Timezone)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	now := time.Now()
+	startsAt, endsAt, err := silenceWindow(args.StartsAt, args.EndsAt, args.Duration, now, loc)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	maxDuration := silencesCfg.MaxDuration
+	if maxDuration <= 0 {
+		maxDuration = defaultSilenceMaxDuration
+	}
+
+	if startsAt.Sub(now) > maxSilenceStartDelay {
+		return mcp.NewToolResultError(fmt.Sprintf("silence must start within %s from now", maxSilenceStartDelay.String())), nil
+	}
+	if !endsAt.After(startsAt) {
+		return mcp.NewToolResultError("silence must end after it starts, use duration for an end relative to the start"), nil
+	}
+	if !endsAt.After(time.Now()) {
+		return mcp.NewToolResultError("silence must end in the future, use duration for an end relative to the start"), nil
+	}
+	if endsAt.Sub(startsAt) > maxDuration {
+		return mcp.NewToolResultError(fmt.Sprintf("silence duration %s exceeds the maximum allowed for backend %q (%s)",
+			endsAt.Sub(startsAt).String(), backendName, maxDuration.String())), nil
+	}
+
+	startsAtDT := strfmt.DateTime(startsAt)
+	endsAtDT := strfmt.DateTime(endsAt)
+	postable := &models.PostableSilence{
+		Silence: models.Silence{
+			Matchers:  matchers,
+			StartsAt:  &startsAtDT,
+			EndsAt:    &endsAtDT,
+			CreatedBy: &createdBy,
+			Comment:   &args.Comment,
+		},
+	}
+
+	auditAttrs := []any{
+		"action", "create_silence",
+		"backend", backendName,
+		"org_id", args.OrgID,
+		"created_by", createdBy,
+		"matchers", formatMatchers(matchers),
+		"starts_at", startsAt.Format(time.RFC3339),
+		"ends_at", endsAt.Format(time.RFC3339),
+		"comment", args.Comment,
+	}
+
+	silenceID, err := tm.dependencies.HandlersManager.CreateAlertmanagerSilence(ctx, backendName, postable, args.OrgID)
+	if err != nil {
+		tm.dependencies.AppCtx.AuditLogger.Error("Silence creation failed", append(auditAttrs, "error", err.Error())...)
+		return mcp.NewToolResultError(fmt.Sprintf("failed to create silence on backend %q: %s", backendName, err.Error())), nil
+	}
+
+	tm.dependencies.AppCtx.AuditLogger.Info("Silence created", append(auditAttrs, "silence_id", silenceID)...)
+
+	encodedResult, err := encoder.Encode(map[string]interface{}{
+		"silence_id": silenceID,
+		"matchers":   formatMatchers(matchers),
+		"starts_at":  startsAt.Format(time.RFC3339),
+		"ends_at":    endsAt.Format(time.RFC3339),
+		"created_by": createdBy,
+		"comment":    args.Comment,
+	})
+	if err != nil {
+		return mcp.NewToolResultError("failed to marshal result: " + err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Silence Created [%s]:\n\n%s", backendName, encodedResult)), nil
+}
+
+// silenceWindow resolves the start and end of a silence. The end is given either as a time or as a
+// Prometheus duration (e.g., '90m', '1d', '1w') counted from the start
+func silenceWindow(startsAtArg, endsAtArg, durationArg string, now time.Time, loc *time.Location) (time.Time, time.Time, error) {
+	startsAt := now
+	if startsAtArg != "" {
+		var err error
+		startsAt, err = parseTime(startsAtArg, now, loc)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid starts_at: %w", err)
+		}
+	}
+
+	switch {
+	case endsAtArg != "" && durationArg != "":
+		return time.Time{}, time.Time{}, fmt.Errorf("use either ends_at or duration, not both")
+	case endsAtArg != "":
+		endsAt, err := parseTime(endsAtArg, now, loc)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid ends_at: %w", err)
+		}
+		return startsAt, endsAt, nil
+	case durationArg != "":
+		duration, err := model.ParseDuration(durationArg)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid duration: %w", err)
+		}
+		return startsAt, startsAt.Add(time.Duration(duration)), nil
+	}
+
+	return time.Time{}, time.Time{}, fmt.Errorf("either ends_at or duration parameter is required")
+}
+
+// silenceWriteConfig returns the silences configuration of a backend, failing when writes are not enabled on it
+func (tm *ToolsManager) silenceWriteConfig(backendName string) (api.SilencesConfig, error) {
+	cfg := tm.dependencies.AppCtx.Config.Backends[backendName].Silences
+	if !cfg.WriteEnabled {
+		return cfg, fmt.Errorf("silence writes are not enabled for backend %q", backendName)
+	}
+	return cfg, nil
+}
+
+// silenceAuthor returns the identity stamped on silence writes.
+// When JWT validation is enabled, an authenticated subject is mandatory
+func (tm *ToolsManager) silenceAuthor(ctx context.Context) (string, error) {
+	if subject := middlewares.JWTSubjectFromContext(ctx); subject != "" {
+		return subject, nil
+	}
+
+	if tm.dependencies.AppCtx.Config.Middleware.JWT.Enabled {
+		return "", fmt.Errorf("silence writes require an authenticated JWT subject")
+	}
+
+	return tm.dependencies.AppCtx.Config.Server.Name, nil
+}
+
+// parseSilenceMatchers validates matchers written as 'name="value"', 'name=~"regex"', 'name!="value"' or 'name!~"regex"'.
+// Like Alertmanager, at least one matcher must not match the empty string, so a silence can not match every alert
+func parseSilenceMatchers(raw []string) (models.Matchers, error) {
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("at least one matcher is required")
+	}
+
+	matchers := make(models.Matchers, 0, len(raw))
+	matchesEverything := true
+
+	for _, r := range raw {
+		matcher, err := labels.ParseMatcher(r)
+		if err != nil {
+			return nil, fmt.Errorf("invalid matcher %q: %w", r, err)
+		}
+		if matcher.Name == "" {
+			return nil, fmt.Errorf("invalid matcher %q: label name is empty", r)
+		}
+
+		if !matcher.Matches("") {
+			matchesEverything = false
+		}
+
+		name := matcher.Name
+		value := matcher.Value
+		isEqual := matcher.Type == labels.MatchEqual || matcher.Type == labels.MatchRegexp
+		isRegex := matcher.Type == labels.MatchRegexp || matcher.Type == labels.MatchNotRegexp
+
+		matchers = append(matchers, &models.Matcher{
+			Name:    &name,
+			Value:   &value,
+			IsEqual: &isEqual,
+			IsRegex: &isRegex,
+		})
+	}
+
+	if matchesEverything {
+		return nil, fmt.Errorf("at least one matcher must not match the empty string")
+	}
+
+	return matchers, nil
+}
@@ -0,0 +1,105 @@
+package tools
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSilenceMatchers(t *testing.T) {
+	tests := []struct {
+		name     string
+		matchers []string
+		want     []string
+		wantErr  bool
+	}{
+		{
+			name:     "equality and regex matchers",
+			matchers: []string{`alertname="HighLatency"`, `namespace=~"prod-.*"`},
+			want:     []string{`alertname="HighLatency"`, `namespace=~"prod-.*"`},
+		},
+		{
+			name:     "negative matchers next to a positive one",
+			matchers: []string{`alertname="HighLatency"`, `severity!="info"`, `pod!~"canary-.*"`},
+			want:     []string{`alertname="HighLatency"`, `severity!="info"`, `pod!~"canary-.*"`},
+		},
+		{
+			name:     "no matchers",
+			matchers: []string{},
+			wantErr:  true,
+		},
+		{
+			name:     "malformed matcher",
+			matchers: []string{`alertname`},
+			wantErr:  true,
+		},
+		{
+			name:     "invalid regex",
+			matchers: []string{`alertname=~"(("`},
+			wantErr:  true,
+		},
+		{
+			name:     "matchers matching everything",
+			matchers: []string{`alertname=~".*"`, `severity!="info"`},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matchers, err := parseSilenceMatchers(tt.matchers)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			got := formatMatchers(matchers)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d matchers, want %d", len(got), len(tt.want))
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("matcher %d = %s, want %s", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSilenceWindow(t *testing.T) {
+	now := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		startsAt  string
+		endsAt    string
+		duration  string
+		wantStart time.Time
+		wantEnd   time.Time
+		wantErr   bool
+	}{
+		{name: "go duration", duration: "90m", wantStart: now, wantEnd: now.Add(90 * time.Minute)},
+		{name: "days", duration: "1d", wantStart: now, wantEnd: now.Add(24 * time.Hour)},
+		{name: "weeks from a later start", startsAt: "now+1h", duration: "1w", wantStart: now.Add(time.Hour), wantEnd: now.Add(169 * time.Hour)},
+		{name: "absolute end", endsAt: "2025-01-15T12:00:00Z", wantStart: now, wantEnd: now.Add(2 * time.Hour)},
+		{name: "both end and duration", endsAt: "now+1h", duration: "1h", wantErr: true},
+		{name: "no end", wantErr: true},
+		{name: "invalid duration", duration: "soon", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, err := silenceWindow(tt.startsAt, tt.endsAt, tt.duration, now, time.UTC)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !start.Equal(tt.wantStart) || !end.Equal(tt.wantEnd) {
+				t.Errorf("got %s..%s, want %s..%s", start, end, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
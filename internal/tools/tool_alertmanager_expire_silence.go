@@ -0,0 +1,85 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func (tm *ToolsManager) HandleToolAlertmanagerExpireSilence(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		Backend   string `json:"backend,omitempty"`
+		SilenceID string `json:"silence_id"`
+		OrgID     string `json:"org_id,omitempty"`
+	}
+
+	argsBytes, err := json.Marshal(request.Params.Arguments)
+	if err != nil {
+		return mcp.NewToolResultError("failed to marshal arguments: " + err.Error()), nil
+	}
+	if err = json.Unmarshal(argsBytes, &args); err != nil {
+		return mcp.NewToolResultError("failed to parse arguments: " + err.Error()), nil
+	}
+
+	encoder, err := tm.resultEncoder(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	backendName, err := tm.resolveAlertmanagerBackend(args.Backend)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	tm.warnIfOrgIDIgnored(backendName, args.OrgID)
+
+	if _, err := tm.silenceWriteConfig(backendName); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	expiredBy, err := tm.silenceAuthor(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if args.SilenceID == "" {
+		return mcp.NewToolResultError("silence_id parameter is required"), nil
+	}
+
+	// Fetch the silence first, so the audit log records what was expired
+	silence, err := tm.dependencies.HandlersManager.AlertmanagerSilence(ctx, backendName, args.SilenceID, args.OrgID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to fetch silence %q from backend %q: %s", args.SilenceID, backendName, err.Error())), nil
+	}
+
+	auditAttrs := []any{
+		"action", "expire_silence",
+		"backend", backendName,
+		"org_id", args.OrgID,
+		"silence_id", args.SilenceID,
+		"expired_by", expiredBy,
+		"created_by", stringValue(silence.CreatedBy),
+		"matchers", formatMatchers(silence.Matchers),
+	}
+
+	err = tm.dependencies.HandlersManager.ExpireAlertmanagerSilence(ctx, backendName, args.SilenceID, args.OrgID)
+	if err != nil {
+		tm.dependencies.AppCtx.AuditLogger.Error("Silence expiry failed", append(auditAttrs, "error", err.Error())...)
+		return mcp.NewToolResultError(fmt.Sprintf("failed to expire silence %q on backend %q: %s", args.SilenceID, backendName, err.Error())), nil
+	}
+
+	tm.dependencies.AppCtx.AuditLogger.Info("Silence expired", auditAttrs...)
+
+	encodedResult, err := encoder.Encode(map[string]interface{}{
+		"silence_id": args.SilenceID,
+		"matchers":   formatMatchers(silence.Matchers),
+		"expired_by": expiredBy,
+	})
+	if err != nil {
+		return mcp.NewToolResultError("failed to marshal result: " + err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Silence Expired [%s]:\n\n%s", backendName, encodedResult)), nil
+}
@@ -0,0 +1,136 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/prometheus/alertmanager/api/v2/models"
+)
+
+const defaultSilencesLimit = 50
+
+func (tm *ToolsManager) HandleToolAlertmanagerSilences(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		Backend  string   `json:"backend,omitempty"`
+		Matchers []string `json:"matchers,omitempty"`
+		State    string   `json:"state,omitempty"`
+		OrgID    string   `json:"org_id,omitempty"`
+		Limit    int      `json:"limit,omitempty"`
+		Offset   int      `json:"offset,omitempty"`
+	}
+
+	argsBytes, err := json.Marshal(request.Params.Arguments)
+	if err != nil {
+		return mcp.NewToolResultError("failed to marshal arguments: " + err.Error()), nil
+	}
+	if err = json.Unmarshal(argsBytes, &args); err != nil {
+		return mcp.NewToolResultError("failed to parse arguments: " + err.Error()), nil
+	}
+
+	encoder, err := tm.resultEncoder(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	backendName, err := tm.resolveAlertmanagerBackend(args.Backend)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	tm.warnIfOrgIDIgnored(backendName, args.OrgID)
+
+	if args.Limit <= 0 {
+		args.Limit = defaultSilencesLimit
+	}
+	if args.Offset < 0 {
+		args.Offset = 0
+	}
+
+	silences, err := tm.dependencies.HandlersManager.AlertmanagerSilences(ctx, backendName, args.Matchers, args.OrgID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to fetch silences from backend %q: %s", backendName, err.Error())), nil
+	}
+
+	// Latest ending silences first
+	sort.SliceStable(silences, func(i, j int) bool {
+		return formatDateTime(silences[i].EndsAt) > formatDateTime(silences[j].EndsAt)
+	})
+
+	entries := make([]map[string]interface{}, 0, len(silences))
+	stateCount := map[string]int{}
+
+	for _, silence := range silences {
+		state := ""
+		if silence.Status != nil && silence.Status.State != nil {
+			state = *silence.Status.State
+		}
+		if args.State != "" && state != args.State {
+			continue
+		}
+
+		stateCount[state]++
+		entries = append(entries, map[string]interface{}{
+			"id":         stringValue(silence.ID),
+			"state":      state,
+			"matchers":   formatMatchers(silence.Matchers),
+			"starts_at":  formatDateTime(silence.StartsAt),
+			"ends_at":    formatDateTime(silence.EndsAt),
+			"created_by": stringValue(silence.CreatedBy),
+			"comment":    stringValue(silence.Comment),
+		})
+	}
+
+	totalSilences := len(entries)
+	start, end := paginationBounds(totalSilences, args.Offset, args.Limit)
+
+	encodedResult, err := encoder.Encode(map[string]interface{}{
+		"total_silences": totalSilences,
+		"by_state":       stateCount,
+		"returned":       end - start,
+		"offset":         args.Offset,
+		"limit":          args.Limit,
+		"has_more":       end < totalSilences,
+		"silences":       entries[start:end],
+	})
+	if err != nil {
+		return mcp.NewToolResultError("failed to marshal result: " + err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Alertmanager Silences [%s]:\n\n%s", backendName, encodedResult)), nil
+}
+
+// formatMatchers renders silence matchers in their PromQL-like text form (e.g., job=~"api.*")
+func formatMatchers(matchers models.Matchers) []string {
+	result := make([]string, 0, len(matchers))
+	for _, matcher := range matchers {
+		if matcher == nil {
+			continue
+		}
+
+		isEqual := matcher.IsEqual == nil || *matcher.IsEqual
+		isRegex := matcher.IsRegex != nil && *matcher.IsRegex
+
+		op := "="
+		switch {
+		case isEqual && isRegex:
+			op = "=~"
+		case !isEqual && isRegex:
+			op = "!~"
+		case !isEqual:
+			op = "!="
+		}
+
+		result = append(result, fmt.Sprintf("%s%s%q", stringValue(matcher.Name), op, stringValue(matcher.Value)))
+	}
+	return result
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
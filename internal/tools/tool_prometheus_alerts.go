@@ -0,0 +1,116 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/prometheus/common/model"
+)
+
+const defaultAlertsLimit = 50
+
+func (tm *ToolsManager) HandleToolAlerts(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		Backend string `json:"backend,omitempty"`
+		Name    string `json:"name,omitempty"`
+		State   string `json:"state,omitempty"`
+		OrgID   string `json:"org_id,omitempty"`
+		Limit   int    `json:"limit,omitempty"`
+		Offset  int    `json:"offset,omitempty"`
+	}
+
+	argsBytes, err := json.Marshal(request.Params.Arguments)
+	if err != nil {
+		return mcp.NewToolResultError("failed to marshal arguments: " + err.Error()), nil
+	}
+	if err = json.Unmarshal(argsBytes, &args); err != nil {
+		return mcp.NewToolResultError("failed to parse arguments: " + err.Error()), nil
+	}
+
+	encoder, err := tm.resultEncoder(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	backendName, err := tm.resolveBackend(args.Backend)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	tm.warnIfOrgIDIgnored(backendName, args.OrgID)
+
+	if args.Name != "" {
+		if _, err := filepath.Match(args.Name, ""); err != nil {
+			return mcp.NewToolResultError("invalid glob pattern: " + err.Error()), nil
+		}
+	}
+
+	if args.Limit <= 0 {
+		args.Limit = defaultAlertsLimit
+	}
+	if args.Offset < 0 {
+		args.Offset = 0
+	}
+
+	alerts, err := tm.dependencies.HandlersManager.Alerts(ctx, backendName, args.OrgID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to fetch alerts from backend %q: %s", backendName, err.Error())), nil
+	}
+
+	// Firing alerts first, then oldest first
+	sort.SliceStable(alerts.Alerts, func(i, j int) bool {
+		a, b := alerts.Alerts[i], alerts.Alerts[j]
+		if a.State != b.State {
+			return a.State == "firing"
+		}
+		return a.ActiveAt.Before(b.ActiveAt)
+	})
+
+	entries := []map[string]interface{}{}
+	stateCount := map[string]int{}
+
+	for _, alert := range alerts.Alerts {
+		name := string(alert.Labels[model.AlertNameLabel])
+		if args.Name != "" {
+			if matched, _ := filepath.Match(args.Name, name); !matched {
+				continue
+			}
+		}
+		if args.State != "" && string(alert.State) != args.State {
+			continue
+		}
+
+		stateCount[string(alert.State)]++
+		entries = append(entries, map[string]interface{}{
+			"name":        name,
+			"state":       string(alert.State),
+			"active_at":   alert.ActiveAt.Format(time.RFC3339),
+			"value":       alert.Value,
+			"labels":      alert.Labels,
+			"annotations": alert.Annotations,
+		})
+	}
+
+	totalAlerts := len(entries)
+	start, end := paginationBounds(totalAlerts, args.Offset, args.Limit)
+
+	encodedResult, err := encoder.Encode(map[string]interface{}{
+		"total_alerts": totalAlerts,
+		"by_state":     stateCount,
+		"returned":     end - start,
+		"offset":       args.Offset,
+		"limit":        args.Limit,
+		"has_more":     end < totalAlerts,
+		"alerts":       entries[start:end],
+	})
+	if err != nil {
+		return mcp.NewToolResultError("failed to marshal result: " + err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Alerts [%s]:\n\n%s", backendName, encodedResult)), nil
+}
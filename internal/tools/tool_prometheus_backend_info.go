@@ -0,0 +1,226 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	v1 "github.com/prometheus/client_golang/api/prometheus/v1"
+)
+
+// defaultLookbackDelta is the Prometheus default when the query.lookback-delta flag is not exposed
+const defaultLookbackDelta = "5m"
+
+// Backend flavors, as inferred from build info and flags
+const (
+	flavorPrometheus      = "prometheus"
+	flavorPrometheusAgent = "prometheus-agent"
+	flavorThanos          = "thanos"
+	flavorMimir           = "mimir"
+	flavorCortex          = "cortex"
+	flavorVictoriaMetrics = "victoriametrics"
+	flavorUnknown         = "unknown"
+)
+
+// buildInfoFlavorHints map names found in build info versions and branches to flavors
+var buildInfoFlavorHints = []struct {
+	substring string
+	flavor    string
+}{
+	{"mimir", flavorMimir},
+	{"cortex", flavorCortex},
+	{"thanos", flavorThanos},
+	{"victoria", flavorVictoriaMetrics},
+}
+
+// thanosFlags only exist on Thanos components
+var thanosFlags = []string{"grpc-address", "endpoint", "store", "query.replica-label"}
+
+// relevantFlagPrefixes select the flags that change how queries behave or how much data is available
+var relevantFlagPrefixes = []string{
+	"enable-feature",
+	"query.",
+	"storage.tsdb.retention",
+	"storage.tsdb.out-of-order",
+	"storage.tsdb.min-block-duration",
+	"storage.tsdb.max-block-duration",
+	"web.enable-admin-api",
+	"web.enable-remote-write-receiver",
+	"web.enable-otlp-receiver",
+}
+
+func (tm *ToolsManager) HandleToolBackendInfo(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		Backend  string `json:"backend,omitempty"`
+		OrgID    string `json:"org_id,omitempty"`
+		AllFlags bool   `json:"all_flags,omitempty"`
+	}
+
+	argsBytes, err := json.Marshal(request.Params.Arguments)
+	if err != nil {
+		return mcp.NewToolResultError("failed to marshal arguments: " + err.Error()), nil
+	}
+	if err = json.Unmarshal(argsBytes, &args); err != nil {
+		return mcp.NewToolResultError("failed to parse arguments: " + err.Error()), nil
+	}
+
+	encoder, err := tm.resultEncoder(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	backendName, err := tm.resolveBackend(args.Backend)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	tm.warnIfOrgIDIgnored(backendName, args.OrgID)
+
+	// Prometheus-compatible backends (Mimir, Thanos, VictoriaMetrics...) implement these endpoints partially,
+	// so each one is reported independently and the call only fails when all of them do
+	errs := map[string]string{}
+
+	buildInfo, err := tm.dependencies.HandlersManager.Buildinfo(ctx, backendName, args.OrgID)
+	if err != nil {
+		errs["buildinfo"] = err.Error()
+	}
+
+	runtimeInfo, err := tm.dependencies.HandlersManager.Runtimeinfo(ctx, backendName, args.OrgID)
+	if err != nil {
+		errs["runtimeinfo"] = err.Error()
+	}
+
+	flags, err := tm.dependencies.HandlersManager.Flags(ctx, backendName, args.OrgID)
+	if err != nil {
+		errs["flags"] = err.Error()
+	}
+
+	if len(errs) == 3 {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to fetch info from backend %q: buildinfo: %s; runtimeinfo: %s; flags: %s",
+			backendName, errs["buildinfo"], errs["runtimeinfo"], errs["flags"])), nil
+	}
+
+	result := map[string]interface{}{
+		"flavor":            backendFlavor(buildInfo, flags),
+		"version":           buildInfo.Version,
+		"revision":          buildInfo.Revision,
+		"branch":            buildInfo.Branch,
+		"build_date":        buildInfo.BuildDate,
+		"go_version":        buildInfo.GoVersion,
+		"storage_retention": storageRetention(runtimeInfo.StorageRetention, flags),
+		"lookback_delta":    lookbackDelta(flags),
+		"feature_flags":     featureFlags(flags),
+		"flags":             selectFlags(flags, args.AllFlags),
+		"errors":            errs,
+	}
+	if _, failed := errs["runtimeinfo"]; !failed {
+		result["start_time"] = runtimeInfo.StartTime.Format(time.RFC3339)
+		result["reload_config_success"] = runtimeInfo.ReloadConfigSuccess
+		result["last_config_time"] = runtimeInfo.LastConfigTime.Format(time.RFC3339)
+	}
+
+	encodedResult, err := encoder.Encode(result)
+	if err != nil {
+		return mcp.NewToolResultError("failed to marshal result: " + err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Backend Info [%s]:\n\n%s", backendName, encodedResult)), nil
+}
+
+// backendFlavor infers the implementation behind a Prometheus-compatible API. Forks usually name themselves
+// in their build info, otherwise flags specific to Prometheus and Thanos are checked. Endpoints a backend
+// does not implement are no evidence of any flavor, so anything else is unknown
+func backendFlavor(buildInfo v1.BuildinfoResult, flags map[string]string) string {
+	build := strings.ToLower(strings.Join([]string{buildInfo.Version, buildInfo.Branch, buildInfo.Revision}, " "))
+	for _, hint := range buildInfoFlavorHints {
+		if strings.Contains(build, hint.substring) {
+			return hint.flavor
+		}
+	}
+
+	for _, name := range thanosFlags {
+		if _, ok := flags[name]; ok {
+			return flavorThanos
+		}
+	}
+	if _, ok := flags["storage.agent.path"]; ok {
+		return flavorPrometheusAgent
+	}
+	if _, ok := flags["storage.tsdb.path"]; ok {
+		return flavorPrometheus
+	}
+
+	return flavorUnknown
+}
+
+// storageRetention prefers the runtime info value and falls back to the retention flags
+func storageRetention(runtimeRetention string, flags map[string]string) string {
+	if runtimeRetention != "" {
+		return runtimeRetention
+	}
+
+	var parts []string
+	if value := flags["storage.tsdb.retention.time"]; value != "" && value != "0s" {
+		parts = append(parts, value)
+	}
+	if value := flags["storage.tsdb.retention.size"]; value != "" && value != "0B" {
+		parts = append(parts, value)
+	}
+	return strings.Join(parts, " or ")
+}
+
+// lookbackDelta returns the configured lookback delta, or the Prometheus default when flags are available
+func lookbackDelta(flags map[string]string) string {
+	if value := flags["query.lookback-delta"]; value != "" {
+		return value
+	}
+	if flags == nil {
+		return ""
+	}
+	return defaultLookbackDelta
+}
+
+// featureFlags returns the enabled feature flags, sorted
+func featureFlags(flags map[string]string) []string {
+	features := []string{}
+	for _, feature := range strings.Split(flags["enable-feature"], ",") {
+		if feature = strings.TrimSpace(feature); feature != "" {
+			features = append(features, feature)
+		}
+	}
+	sort.Strings(features)
+	return features
+}
+
+// selectFlags returns the flags relevant for querying, or every flag when requested, sorted by name
+func selectFlags(flags map[string]string, all bool) []map[string]interface{} {
+	names := make([]string, 0, len(flags))
+	for name := range flags {
+		if all || hasAnyPrefix(name, relevantFlagPrefixes) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	result := make([]map[string]interface{}, 0, len(names))
+	for _, name := range names {
+		result = append(result, map[string]interface{}{
+			"name":  name,
+			"value": flags[name],
+		})
+	}
+	return result
+}
+
+func hasAnyPrefix(value string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(value, prefix) {
+			return true
+		}
+	}
+	return false
+}
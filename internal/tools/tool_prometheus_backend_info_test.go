@@ -0,0 +1,35 @@
+package tools
+
+import (
+	"testing"
+
+	v1 "github.com/prometheus/client_golang/api/prometheus/v1"
+)
+
+func TestBackendFlavor(t *testing.T) {
+	prometheusBuild := v1.BuildinfoResult{Version: "3.5.0", Revision: "8be3a9560fbdd18a94dedec4b747c35178177202", Branch: "HEAD", GoVersion: "go1.24.5"}
+
+	tests := []struct {
+		name      string
+		buildInfo v1.BuildinfoResult
+		flags     map[string]string
+		want      string
+	}{
+		{"prometheus flags", prometheusBuild, map[string]string{"storage.tsdb.path": "/prometheus"}, flavorPrometheus},
+		{"prometheus agent flags", prometheusBuild, map[string]string{"storage.agent.path": "/agent"}, flavorPrometheusAgent},
+		{"thanos flags", prometheusBuild, map[string]string{"grpc-address": "0.0.0.0:10901"}, flavorThanos},
+		{"mimir branch in build info", v1.BuildinfoResult{Version: "2.10.0", Branch: "release-2.10-mimir"}, nil, flavorMimir},
+		{"cortex version in build info", v1.BuildinfoResult{Version: "1.18.1-cortex"}, nil, flavorCortex},
+		{"only build info", prometheusBuild, nil, flavorUnknown},
+		{"only a version", v1.BuildinfoResult{Version: "2.24.0"}, nil, flavorUnknown},
+		{"nothing to tell", v1.BuildinfoResult{}, nil, flavorUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := backendFlavor(tt.buildInfo, tt.flags); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	v1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+)
+
+const (
+	defaultCardinalityLimit = 10
+	cardinalityTopValues    = 5
+
+	// cardinalityMaxSeries caps the series fetched by the metric drill-down, so an exploding metric
+	// cannot load millions of series into the server
+	cardinalityMaxSeries = 10000
+)
+
+func (tm *ToolsManager) HandleToolCardinality(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		Backend  string `json:"backend,omitempty"`
+		Metric   string `json:"metric,omitempty"`
+		Start    string `json:"start,omitempty"`
+		End      string `json:"end,omitempty"`
+		Timezone string `json:"timezone,omitempty"`
+		OrgID    string `json:"org_id,omitempty"`
+		Limit    int    `json:"limit,omitempty"`
+	}
+
+	argsBytes, err := json.Marshal(request.Params.Arguments)
+	if err != nil {
+		return mcp.NewToolResultError("failed to marshal arguments: " + err.Error()), nil
+	}
+	if err = json.Unmarshal(argsBytes, &args); err != nil {
+		return mcp.NewToolResultError("failed to parse arguments: " + err.Error()), nil
+	}
+
+	encoder, err := tm.resultEncoder(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	backendName, err := tm.resolveBackend(args.Backend)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	tm.warnIfOrgIDIgnored(backendName, args.OrgID)
+
+	if args.Limit <= 0 {
+		args.Limit = defaultCardinalityLimit
+	}
+
+	if args.Metric != "" {
+		return tm.metricCardinality(ctx, encoder, backendName, args.Metric, args.Start, args.End, args.Timezone, args.OrgID, args.Limit)
+	}
+
+	stats, err := tm.dependencies.HandlersManager.TSDB(ctx, backendName, args.Limit, args.OrgID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to fetch TSDB stats from backend %q: %s", backendName, err.Error())), nil
+	}
+
+	encodedResult, err := encoder.Encode(map[string]interface{}{
+		"head_series":                     stats.HeadStats.NumSeries,
+		"head_label_pairs":                stats.HeadStats.NumLabelPairs,
+		"head_chunks":                     stats.HeadStats.ChunkCount,
+		"top_metrics_by_series":           topStats(stats.SeriesCountByMetricName, args.Limit),
+		"top_labels_by_value_count":       topStats(stats.LabelValueCountByLabelName, args.Limit),
+		"top_labels_by_memory_bytes":      topStats(stats.MemoryInBytesByLabelName, args.Limit),
+		"top_label_value_pairs_by_series": topStats(stats.SeriesCountByLabelValuePair, args.Limit),
+	})
+	if err != nil {
+		return mcp.NewToolResultError("failed to marshal result: " + err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Cardinality [%s]:\n\n%s", backendName, encodedResult)), nil
+}
+
+// metricCardinality computes the per-label cardinality of a single metric through the series API. At most
+// cardinalityMaxSeries series are analyzed, the result tells when the metric has more
+func (tm *ToolsManager) metricCardinality(ctx context.Context, encoder resultEncoder, backendName, metric, start, end, timezone, orgID string, limit int) (*mcp.CallToolResult, error) {
+	startTime, endTime, err := parseTimeWindow(start, end, timezone)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	selector := fmt.Sprintf("{%s=%q}", model.MetricNameLabel, metric)
+	series, err := tm.dependencies.HandlersManager.Series(ctx, backendName, []string{selector}, startTime, endTime, cardinalityMaxSeries+1, orgID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to fetch series from backend %q: %s", backendName, err.Error())), nil
+	}
+
+	// One extra series tells whether the metric has more series than the cap
+	truncated := len(series) > cardinalityMaxSeries
+	if truncated {
+		series = series[:cardinalityMaxSeries]
+	}
+
+	// Count the series carrying each value of each label
+	valueCounts := map[model.LabelName]map[model.LabelValue]int{}
+	for _, labelSet := range series {
+		for name, value := range labelSet {
+			if name == model.MetricNameLabel {
+				continue
+			}
+			if valueCounts[name] == nil {
+				valueCounts[name] = map[model.LabelValue]int{}
+			}
+			valueCounts[name][value]++
+		}
+	}
+
+	labels := make([]map[string]interface{}, 0, len(valueCounts))
+	for name, values := range valueCounts {
+		topValues := make([]v1.Stat, 0, len(values))
+		for value, count := range values {
+			topValues = append(topValues, v1.Stat{Name: string(value), Value: uint64(count)})
+		}
+
+		labels = append(labels, map[string]interface{}{
+			"label":           string(name),
+			"distinct_values": len(values),
+			"top_values":      topStats(topValues, cardinalityTopValues),
+		})
+	}
+
+	sort.Slice(labels, func(i, j int) bool {
+		a, b := labels[i]["distinct_values"].(int), labels[j]["distinct_values"].(int)
+		if a != b {
+			return a > b
+		}
+		return labels[i]["label"].(string) < labels[j]["label"].(string)
+	})
+	if len(labels) > limit {
+		labels = labels[:limit]
+	}
+
+	encodedResult, err := encoder.Encode(map[string]interface{}{
+		"metric":          metric,
+		"analyzed_series": len(series),
+		"truncated":       truncated,
+		"labels":          labels,
+	})
+	if err != nil {
+		return mcp.NewToolResultError("failed to marshal result: " + err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Metric Cardinality [%s]:\n\nMetric: %s\nStart: %s\nEnd: %s\n\n%s",
+		backendName, metric, startTime.Format(time.RFC3339), endTime.Format(time.RFC3339), encodedResult)), nil
+}
+
+// topStats returns the biggest stats first, limited to the given amount
+func topStats(stats []v1.Stat, limit int) []map[string]interface{} {
+	sorted := make([]v1.Stat, len(stats))
+	copy(sorted, stats)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Value != sorted[j].Value {
+			return sorted[i].Value > sorted[j].Value
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	if len(sorted) > limit {
+		sorted = sorted[:limit]
+	}
+
+	result := make([]map[string]interface{}, 0, len(sorted))
+	for _, stat := range sorted {
+		result = append(result, map[string]interface{}{
+			"name":  stat.Name,
+			"value": stat.Value,
+		})
+	}
+	return result
+}
@@ -0,0 +1,263 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/prometheus/common/model"
+)
+
+const (
+	defaultCompareLimit = 50
+	compareDigits       = 6
+	percentChangeDigits = 4
+)
+
+var defaultCompareBaselines = []string{"1d", "7d"}
+
+func (tm *ToolsManager) HandleToolCompare(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		Backend   string   `json:"backend,omitempty"`
+		Query     string   `json:"query"`
+		Start     string   `json:"start,omitempty"`
+		End       string   `json:"end,omitempty"`
+		Timezone  string   `json:"timezone,omitempty"`
+		Step      string   `json:"step,omitempty"`
+		MaxPoints int      `json:"max_points,omitempty"`
+		Baselines []string `json:"baselines,omitempty"`
+		OrgID     string   `json:"org_id,omitempty"`
+		Limit     int      `json:"limit,omitempty"`
+	}
+
+	argsBytes, err := json.Marshal(request.Params.Arguments)
+	if err != nil {
+		return mcp.NewToolResultError("failed to marshal arguments: " + err.Error()), nil
+	}
+	if err = json.Unmarshal(argsBytes, &args); err != nil {
+		return mcp.NewToolResultError("failed to parse arguments: " + err.Error()), nil
+	}
+
+	encoder, err := tm.resultEncoder(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	backendName, err := tm.resolveBackend(args.Backend)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	tm.warnIfOrgIDIgnored(backendName, args.OrgID)
+
+	if args.Query == "" {
+		return mcp.NewToolResultError("query parameter is required"), nil
+	}
+	if result := tm.preflightQuery(args.Query); result != nil {
+		return result, nil
+	}
+
+	startTime, endTime, err := parseTimeWindow(args.Start, args.End, args.Timezone)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	step, stepMode, err := resolveStep(args.Step, tm.maxPoints(args.MaxPoints), startTime, endTime)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if len(args.Baselines) == 0 {
+		args.Baselines = defaultCompareBaselines
+	}
+	offsets := make([]model.Duration, 0, len(args.Baselines))
+	for _, baseline := range args.Baselines {
+		offset, err := model.ParseDuration(baseline)
+		if err != nil || offset <= 0 {
+			return mcp.NewToolResultError(fmt.Sprintf("invalid baseline %q, use a positive duration (e.g., '1d', '7d')", baseline)), nil
+		}
+		offsets = append(offsets, offset)
+	}
+
+	if args.Limit <= 0 {
+		args.Limit = defaultCompareLimit
+	}
+
+	current, err := tm.queryRangeMatrix(ctx, backendName, args.Query, startTime, endTime, step, args.OrgID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to execute range query on backend %q: %s", backendName, err.Error())), nil
+	}
+	currentStats := statsByLabelSet(current)
+
+	rows := []map[string]interface{}{}
+	summaries := make([]map[string]interface{}, 0, len(offsets))
+
+	for _, offset := range offsets {
+		shift := time.Duration(offset)
+		baseline, err := tm.queryRangeMatrix(ctx, backendName, args.Query, startTime.Add(-shift), endTime.Add(-shift), step, args.OrgID)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to execute baseline range query (%s ago) on backend %q: %s", offset, backendName, err.Error())), nil
+		}
+		baselineStats := statsByLabelSet(baseline)
+
+		baselineRows, summary := comparePeriods(currentStats, baselineStats, offset.String())
+		rows = append(rows, baselineRows...)
+		summaries = append(summaries, summary)
+	}
+
+	// Biggest relative changes first, series without a comparable baseline last
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, aOk := rows[i]["percent_change"].(float64)
+		b, bOk := rows[j]["percent_change"].(float64)
+		if aOk != bOk {
+			return aOk
+		}
+		if aOk && math.Abs(a) != math.Abs(b) {
+			return math.Abs(a) > math.Abs(b)
+		}
+		return rows[i]["series"].(string) < rows[j]["series"].(string)
+	})
+
+	totalRows := len(rows)
+	if len(rows) > args.Limit {
+		rows = rows[:args.Limit]
+	}
+
+	encodedResult, err := encoder.Encode(map[string]interface{}{
+		"current_series": len(currentStats),
+		"summary":        summaries,
+		"total_rows":     totalRows,
+		"returned":       len(rows),
+		"comparisons":    rows,
+	})
+	if err != nil {
+		return mcp.NewToolResultError("failed to marshal result: " + err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Period Comparison [%s]:\n\nQuery: %s\nStart: %s\nEnd: %s\nStep: %s (%s)\nBaselines: %s\n\n%s",
+		backendName, args.Query, startTime.Format(time.RFC3339), endTime.Format(time.RFC3339), step.String(), stepMode,
+		strings.Join(args.Baselines, ", "), encodedResult)), nil
+}
+
+// queryRangeMatrix executes a range query and asserts its result is a matrix
+func (tm *ToolsManager) queryRangeMatrix(ctx context.Context, backendName, query string, startTime, endTime time.Time, step time.Duration, orgID string) (model.Matrix, error) {
+	result, err := tm.dependencies.HandlersManager.QueryRange(ctx, backendName, query, startTime, endTime, step, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	matrix, ok := result.(model.Matrix)
+	if !ok {
+		return nil, fmt.Errorf("unexpected result type %T, expected a matrix", result)
+	}
+	return matrix, nil
+}
+
+// statsByLabelSet computes the statistics of every series, keyed by its label set
+func statsByLabelSet(matrix model.Matrix) map[string]seriesStats {
+	result := make(map[string]seriesStats, len(matrix))
+	for _, stream := range matrix {
+		result[stream.Metric.String()] = computeSeriesStats(stream.Values)
+	}
+	return result
+}
+
+// comparePeriods aligns current and baseline series by label set, returning one row per series
+// and the aggregated change over every matched series
+func comparePeriods(current, baseline map[string]seriesStats, baselineName string) ([]map[string]interface{}, map[string]interface{}) {
+	rows := []map[string]interface{}{}
+	var matched, newSeries, goneSeries, increased, decreased, unchanged int
+	var currentTotal, baselineTotal float64
+
+	for series, currentStats := range current {
+		row := map[string]interface{}{
+			"series":         series,
+			"baseline":       baselineName,
+			"status":         "new",
+			"current_mean":   roundFloat(currentStats.Mean, compareDigits),
+			"baseline_mean":  nil,
+			"delta":          nil,
+			"percent_change": nil,
+			"current_max":    roundFloat(currentStats.Max, compareDigits),
+			"baseline_max":   nil,
+		}
+
+		baselineStats, ok := baseline[series]
+		if !ok {
+			newSeries++
+			rows = append(rows, row)
+			continue
+		}
+
+		matched++
+		delta := currentStats.Mean - baselineStats.Mean
+		percentChange := percentChange(currentStats.Mean, baselineStats.Mean)
+
+		row["status"] = "matched"
+		row["baseline_mean"] = roundFloat(baselineStats.Mean, compareDigits)
+		row["baseline_max"] = roundFloat(baselineStats.Max, compareDigits)
+		row["delta"] = roundFloat(delta, compareDigits)
+		row["percent_change"] = roundFloat(percentChange, percentChangeDigits)
+		rows = append(rows, row)
+
+		if !math.IsNaN(delta) {
+			currentTotal += currentStats.Mean
+			baselineTotal += baselineStats.Mean
+			switch {
+			case delta > 0:
+				increased++
+			case delta < 0:
+				decreased++
+			default:
+				unchanged++
+			}
+		}
+	}
+
+	for series, baselineStats := range baseline {
+		if _, ok := current[series]; ok {
+			continue
+		}
+		goneSeries++
+		rows = append(rows, map[string]interface{}{
+			"series":         series,
+			"baseline":       baselineName,
+			"status":         "gone",
+			"current_mean":   nil,
+			"baseline_mean":  roundFloat(baselineStats.Mean, compareDigits),
+			"delta":          nil,
+			"percent_change": nil,
+			"current_max":    nil,
+			"baseline_max":   roundFloat(baselineStats.Max, compareDigits),
+		})
+	}
+
+	summary := map[string]interface{}{
+		"baseline":             baselineName,
+		"matched_series":       matched,
+		"new_series":           newSeries,
+		"gone_series":          goneSeries,
+		"increased":            increased,
+		"decreased":            decreased,
+		"unchanged":            unchanged,
+		"current_total_mean":   roundFloat(currentTotal, compareDigits),
+		"baseline_total_mean":  roundFloat(baselineTotal, compareDigits),
+		"total_delta":          roundFloat(currentTotal-baselineTotal, compareDigits),
+		"total_percent_change": roundFloat(percentChange(currentTotal, baselineTotal), percentChangeDigits),
+	}
+
+	return rows, summary
+}
+
+// percentChange returns the relative change from baseline to current, NaN when the baseline is zero
+func percentChange(current, baseline float64) float64 {
+	if baseline == 0 {
+		return math.NaN()
+	}
+	return (current - baseline) / math.Abs(baseline) * 100
+}
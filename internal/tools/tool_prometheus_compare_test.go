@@ -0,0 +1,72 @@
+package tools
+
+import (
+	"testing"
+)
+
+func TestComparePeriods(t *testing.T) {
+	current := map[string]seriesStats{
+		`{job="api"}`:    {Count: 2, Mean: 150, Max: 200},
+		`{job="worker"}`: {Count: 2, Mean: 10, Max: 10},
+		`{job="new"}`:    {Count: 1, Mean: 5, Max: 5},
+	}
+	baseline := map[string]seriesStats{
+		`{job="api"}`:    {Count: 2, Mean: 100, Max: 120},
+		`{job="worker"}`: {Count: 2, Mean: 20, Max: 25},
+		`{job="gone"}`:   {Count: 2, Mean: 1, Max: 1},
+	}
+
+	rows, summary := comparePeriods(current, baseline, "7d")
+
+	if len(rows) != 4 {
+		t.Fatalf("got %d rows, want 4", len(rows))
+	}
+
+	wantPercent := map[string]interface{}{
+		`{job="api"}`:    50.0,
+		`{job="worker"}`: -50.0,
+		`{job="new"}`:    nil,
+		`{job="gone"}`:   nil,
+	}
+	for _, row := range rows {
+		series := row["series"].(string)
+		if row["percent_change"] != wantPercent[series] {
+			t.Errorf("%s percent_change = %v, want %v", series, row["percent_change"], wantPercent[series])
+		}
+	}
+
+	want := map[string]interface{}{
+		"matched_series":       2,
+		"new_series":           1,
+		"gone_series":          1,
+		"increased":            1,
+		"decreased":            1,
+		"total_delta":          40.0,
+		"total_percent_change": 33.33,
+	}
+	for key, value := range want {
+		if summary[key] != value {
+			t.Errorf("summary[%q] = %v, want %v", key, summary[key], value)
+		}
+	}
+}
+
+func TestComparePeriodsSmallValues(t *testing.T) {
+	// Sub-millisecond latencies in seconds
+	current := map[string]seriesStats{`{job="rpc"}`: {Count: 2, Mean: 0.000003, Max: 0.000004}}
+	baseline := map[string]seriesStats{`{job="rpc"}`: {Count: 2, Mean: 0.000001, Max: 0.000002}}
+
+	rows, _ := comparePeriods(current, baseline, "1d")
+
+	want := map[string]interface{}{
+		"current_mean":   0.000003,
+		"baseline_mean":  0.000001,
+		"delta":          0.000002,
+		"percent_change": 200.0,
+	}
+	for key, value := range want {
+		if rows[0][key] != value {
+			t.Errorf("%s = %v, want %v", key, rows[0][key], value)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/prometheus/common/model"
+)
+
+const defaultExemplarsLimit = 100
+
+var (
+	traceURLPlaceholderRe = regexp.MustCompile(`\{\{\s*([a-zA-Z_][a-zA-Z0-9_]*)\s*\}\}`)
+
+	// Instrumentation libraries do not agree on the name of the trace ID label
+	traceIDLabels = []model.LabelName{"trace_id", "traceID", "traceId", "TraceID"}
+)
+
+func (tm *ToolsManager) HandleToolExemplars(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		Backend  string `json:"backend,omitempty"`
+		Query    string `json:"query"`
+		Start    string `json:"start,omitempty"`
+		End      string `json:"end,omitempty"`
+		Timezone string `json:"timezone,omitempty"`
+		OrgID    string `json:"org_id,omitempty"`
+		Limit    int    `json:"limit,omitempty"`
+	}
+
+	argsBytes, err := json.Marshal(request.Params.Arguments)
+	if err != nil {
+		return mcp.NewToolResultError("failed to marshal arguments: " + err.Error()), nil
+	}
+	if err = json.Unmarshal(argsBytes, &args); err != nil {
+		return mcp.NewToolResultError("failed to parse arguments: " + err.Error()), nil
+	}
+
+	encoder, err := tm.resultEncoder(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	backendName, err := tm.resolveBackend(args.Backend)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	tm.warnIfOrgIDIgnored(backendName, args.OrgID)
+
+	if args.Query == "" {
+		return mcp.NewToolResultError("query parameter is required"), nil
+	}
+
+	startTime, endTime, err := parseTimeWindow(args.Start, args.End, args.Timezone)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if args.Limit <= 0 {
+		args.Limit = defaultExemplarsLimit
+	}
+
+	results, err := tm.dependencies.HandlersManager.QueryExemplars(ctx, backendName, args.Query, startTime, endTime, args.OrgID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to query exemplars on backend %q: %s", backendName, err.Error())), nil
+	}
+
+	traceURLTemplate := tm.dependencies.AppCtx.Config.Backends[backendName].TraceURLTemplate
+
+	totalExemplars := 0
+	returned := 0
+	series := make([]map[string]interface{}, 0, len(results))
+
+	for _, result := range results {
+		totalExemplars += len(result.Exemplars)
+
+		exemplars := make([]map[string]interface{}, 0, len(result.Exemplars))
+		for _, exemplar := range result.Exemplars {
+			if returned >= args.Limit {
+				break
+			}
+			returned++
+
+			// Values are formatted like sample values in query results, as JSON has no NaN or Inf
+			entry := map[string]interface{}{
+				"timestamp": exemplar.Timestamp.Time().Format(time.RFC3339Nano),
+				"value":     exemplar.Value.String(),
+				"labels":    exemplar.Labels,
+			}
+			if traceURLTemplate != "" {
+				entry["trace_url"] = renderTraceURL(traceURLTemplate, exemplar.Labels)
+			}
+			exemplars = append(exemplars, entry)
+		}
+
+		if len(exemplars) == 0 {
+			continue
+		}
+		series = append(series, map[string]interface{}{
+			"series_labels": result.SeriesLabels,
+			"exemplars":     exemplars,
+		})
+	}
+
+	encodedResult, err := encoder.Encode(map[string]interface{}{
+		"total_exemplars": totalExemplars,
+		"returned":        returned,
+		"limit":           args.Limit,
+		"has_more":        returned < totalExemplars,
+		"series":          series,
+	})
+	if err != nil {
+		return mcp.NewToolResultError("failed to marshal result: " + err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Exemplars [%s]:\n\nQuery: %s\nStart: %s\nEnd: %s\n\n%s",
+		backendName, args.Query, startTime.Format(time.RFC3339), endTime.Format(time.RFC3339), encodedResult)), nil
+}
+
+// renderTraceURL replaces every {{label}} placeholder in the template with the escaped exemplar label value.
+// {{trace_id}} also resolves the usual trace ID label spellings. Returns empty when a placeholder can not be resolved
+func renderTraceURL(template string, labels model.LabelSet) string {
+	var rendered strings.Builder
+	last := 0
+
+	for _, match := range traceURLPlaceholderRe.FindAllStringSubmatchIndex(template, -1) {
+		value, ok := traceURLLabel(labels, model.LabelName(template[match[2]:match[3]]))
+		if !ok {
+			return ""
+		}
+
+		rendered.WriteString(template[last:match[0]])
+		// Values land either in the path or, past a '?' or '#', in the query string or fragment
+		if strings.ContainsAny(template[:match[0]], "?#") {
+			rendered.WriteString(url.QueryEscape(value))
+		} else {
+			rendered.WriteString(url.PathEscape(value))
+		}
+		last = match[1]
+	}
+
+	rendered.WriteString(template[last:])
+	return rendered.String()
+}
+
+// traceURLLabel returns the value of a trace URL placeholder label
+func traceURLLabel(labels model.LabelSet, name model.LabelName) (string, bool) {
+	if value, ok := labels[name]; ok {
+		return string(value), true
+	}
+
+	if name == "trace_id" {
+		for _, alias := range traceIDLabels {
+			if value, ok := labels[alias]; ok {
+				return string(value), true
+			}
+		}
+	}
+
+	return "", false
+}
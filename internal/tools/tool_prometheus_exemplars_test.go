@@ -0,0 +1,61 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/prometheus/common/model"
+)
+
+func TestRenderTraceURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		labels   model.LabelSet
+		want     string
+	}{
+		{
+			name:     "trace_id label",
+			template: "https://tempo.example.com/trace/{{trace_id}}",
+			labels:   model.LabelSet{"trace_id": "abc123"},
+			want:     "https://tempo.example.com/trace/abc123",
+		},
+		{
+			name:     "traceID alias",
+			template: "https://jaeger.example.com/trace/{{ trace_id }}",
+			labels:   model.LabelSet{"traceID": "abc123"},
+			want:     "https://jaeger.example.com/trace/abc123",
+		},
+		{
+			name:     "several placeholders",
+			template: "https://tempo.example.com/trace/{{trace_id}}?span={{span_id}}",
+			labels:   model.LabelSet{"trace_id": "abc123", "span_id": "def456"},
+			want:     "https://tempo.example.com/trace/abc123?span=def456",
+		},
+		{
+			name:     "escaped path value",
+			template: "https://tempo.example.com/trace/{{trace_id}}",
+			labels:   model.LabelSet{"trace_id": "../admin?x=1"},
+			want:     "https://tempo.example.com/trace/..%2Fadmin%3Fx=1",
+		},
+		{
+			name:     "escaped query value",
+			template: "https://grafana.example.com/explore?trace={{trace_id}}&service={{service}}",
+			labels:   model.LabelSet{"trace_id": "abc123", "service": "api&debug=true"},
+			want:     "https://grafana.example.com/explore?trace=abc123&service=api%26debug%3Dtrue",
+		},
+		{
+			name:     "missing label",
+			template: "https://tempo.example.com/trace/{{trace_id}}",
+			labels:   model.LabelSet{"span_id": "def456"},
+			want:     "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := renderTraceURL(tt.template, tt.labels); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
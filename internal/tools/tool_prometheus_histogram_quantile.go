@@ -0,0 +1,285 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"prometheus-mcp/internal/promql"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/prometheus/common/model"
+)
+
+const (
+	histogramKindClassic = "classic"
+	histogramKindNative  = "native"
+
+	// quantileLabel is the synthetic label carrying the quantile of each result series
+	quantileLabel model.LabelName = "quantile"
+
+	defaultHistogramRateWindow = "5m"
+)
+
+var defaultHistogramQuantiles = []float64{0.5, 0.9, 0.99}
+
+func (tm *ToolsManager) HandleToolHistogramQuantile(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		Backend    string    `json:"backend,omitempty"`
+		Metric     string    `json:"metric"`
+		Kind       string    `json:"kind,omitempty"`
+		Matchers   []string  `json:"matchers,omitempty"`
+		GroupBy    []string  `json:"group_by,omitempty"`
+		Quantiles  []float64 `json:"quantiles,omitempty"`
+		RateWindow string    `json:"rate_window,omitempty"`
+		Time       string    `json:"time,omitempty"`
+		Start      string    `json:"start,omitempty"`
+		End        string    `json:"end,omitempty"`
+		Step       string    `json:"step,omitempty"`
+		MaxPoints  int       `json:"max_points,omitempty"`
+		Limit      int       `json:"limit,omitempty"`
+		Offset     int       `json:"offset,omitempty"`
+		MaxSamples int       `json:"max_samples,omitempty"`
+		Timezone   string    `json:"timezone,omitempty"`
+		OrgID      string    `json:"org_id,omitempty"`
+	}
+
+	argsBytes, err := json.Marshal(request.Params.Arguments)
+	if err != nil {
+		return mcp.NewToolResultError("failed to marshal arguments: " + err.Error()), nil
+	}
+	if err = json.Unmarshal(argsBytes, &args); err != nil {
+		return mcp.NewToolResultError("failed to parse arguments: " + err.Error()), nil
+	}
+
+	encoder, err := tm.resultEncoder(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	backendName, err := tm.resolveBackend(args.Backend)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	tm.warnIfOrgIDIgnored(backendName, args.OrgID)
+
+	metric := strings.TrimSuffix(args.Metric, "_bucket")
+	if metric == "" {
+		return mcp.NewToolResultError("metric parameter is required"), nil
+	}
+
+	if len(args.Quantiles) == 0 {
+		args.Quantiles = defaultHistogramQuantiles
+	}
+	for _, q := range args.Quantiles {
+		if q < 0 || q > 1 {
+			return mcp.NewToolResultError(fmt.Sprintf("invalid quantile %v, use values between 0 and 1", q)), nil
+		}
+	}
+
+	if args.RateWindow == "" {
+		args.RateWindow = defaultHistogramRateWindow
+	}
+	if _, err := model.ParseDuration(args.RateWindow); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("invalid rate_window %q: %s", args.RateWindow, err.Error())), nil
+	}
+
+	kind := args.Kind
+	switch kind {
+	case histogramKindClassic, histogramKindNative:
+	case "":
+		kind, err = tm.detectHistogramKind(ctx, backendName, metric, args.OrgID)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+	default:
+		return mcp.NewToolResultError(fmt.Sprintf("invalid kind %q, use %s or %s", args.Kind, histogramKindClassic, histogramKindNative)), nil
+	}
+
+	queries := make([]string, 0, len(args.Quantiles))
+	for _, q := range args.Quantiles {
+		query := buildHistogramQuantileQuery(kind, metric, args.Matchers, args.GroupBy, q, args.RateWindow)
+		if _, err := promql.Parse(query); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("invalid matchers or group_by, generated query %q: %s", query, err.Error())), nil
+		}
+		queries = append(queries, query)
+	}
+
+	isRange := args.Start != "" || args.End != ""
+	header := fmt.Sprintf("Metric: %s\nHistogram: %s\nQueries:\n  %s", metric, kind, strings.Join(queries, "\n  "))
+
+	var result interface{}
+	var pagination map[string]interface{}
+	if isRange {
+		startTime, endTime, err := parseTimeWindow(args.Start, args.End, args.Timezone)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		step, stepMode, err := resolveStep(args.Step, tm.maxPoints(args.MaxPoints), startTime, endTime)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		header += fmt.Sprintf("\nStart: %s\nEnd: %s\nStep: %s (%s)",
+			startTime.Format(time.RFC3339), endTime.Format(time.RFC3339), step.String(), stepMode)
+
+		matrix := model.Matrix{}
+		for i, query := range queries {
+			streams, err := tm.queryRangeMatrix(ctx, backendName, query, startTime, endTime, step, args.OrgID)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to execute range query on backend %q: %s", backendName, err.Error())), nil
+			}
+			for _, stream := range streams {
+				tagged := *stream
+				tagged.Metric = tagQuantile(stream.Metric, args.Quantiles[i])
+				matrix = append(matrix, &tagged)
+			}
+		}
+		result, pagination = applyResultLimits(matrix, tm.limitsFor([]string{backendName}, args.Limit, args.Offset, args.MaxSamples))
+	} else {
+		loc, err := loadTimezone(args.Timezone)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		timestamp := time.Now().In(loc)
+		if args.Time != "" {
+			timestamp, err = parseTime(args.Time, time.Now(), loc)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+		}
+		header += fmt.Sprintf("\nTimestamp: %s", timestamp.Format(time.RFC3339))
+
+		rows := []map[string]interface{}{}
+		for i, query := range queries {
+			value, err := tm.dependencies.HandlersManager.Query(ctx, backendName, query, timestamp, args.OrgID)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to execute query on backend %q: %s", backendName, err.Error())), nil
+			}
+			vector, ok := value.(model.Vector)
+			if !ok {
+				return mcp.NewToolResultError(fmt.Sprintf("unexpected result type %T, expected a vector", value)), nil
+			}
+			for _, sample := range vector {
+				rows = append(rows, map[string]interface{}{
+					"group":    sample.Metric.String(),
+					"quantile": args.Quantiles[i],
+					"value":    roundFloat(float64(sample.Value), summaryDigits),
+				})
+			}
+		}
+		sort.SliceStable(rows, func(i, j int) bool {
+			if rows[i]["group"] != rows[j]["group"] {
+				return rows[i]["group"].(string) < rows[j]["group"].(string)
+			}
+			return rows[i]["quantile"].(float64) < rows[j]["quantile"].(float64)
+		})
+		result = map[string]interface{}{
+			"total": len(rows),
+			"rows":  rows,
+		}
+	}
+
+	encodedResult, err := encoder.Encode(result)
+	if err != nil {
+		return mcp.NewToolResultError("failed to marshal result: " + err.Error()), nil
+	}
+
+	paginationText, err := formatPagination(encoder, pagination)
+	if err != nil {
+		return mcp.NewToolResultError("failed to marshal pagination: " + err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Histogram Quantiles [%s]:\n\n%s\n\nResults:\n%s%s", backendName, header, encodedResult, paginationText)), nil
+}
+
+// detectHistogramKind tells whether a histogram is exposed as classic _bucket series or as a native histogram.
+// Metadata reports both as "histogram", so the series found in the probe window decide. Without any series,
+// the kind cannot be told apart and has to be passed explicitly
+func (tm *ToolsManager) detectHistogramKind(ctx context.Context, backendName, metric, orgID string) (string, error) {
+	metadata, err := tm.fetchMetricMetadata(ctx, backendName, metric, orgID)
+	if err != nil {
+		tm.dependencies.AppCtx.Logger.Warn("Failed to fetch histogram metadata",
+			"backend", backendName,
+			"metric", metric,
+			"error", err.Error(),
+		)
+	}
+	if entry, ok := metadata[metric]; ok && entry.Type != "histogram" && entry.Type != "gaugehistogram" {
+		return "", fmt.Errorf("metric %q is a %s, not a histogram", metric, entry.Type)
+	}
+
+	hasBuckets, err := tm.hasRecentSeries(ctx, backendName, metric+"_bucket", orgID)
+	if err != nil {
+		return "", err
+	}
+	if hasBuckets {
+		return histogramKindClassic, nil
+	}
+
+	hasNative, err := tm.hasRecentSeries(ctx, backendName, metric, orgID)
+	if err != nil {
+		return "", err
+	}
+	if hasNative {
+		return histogramKindNative, nil
+	}
+
+	return "", fmt.Errorf("cannot detect the kind of histogram %q: no %s_bucket or %s series in the last %s, pass 'kind' explicitly",
+		metric, metric, metric, defaultTimeWindow)
+}
+
+// hasRecentSeries tells whether a metric has series in the histogram probe window.
+// A single series is requested, as bucket metrics are often the biggest families of a server
+func (tm *ToolsManager) hasRecentSeries(ctx context.Context, backendName, name, orgID string) (bool, error) {
+	endTime := time.Now()
+	selector := fmt.Sprintf("{%s=%q}", model.MetricNameLabel, name)
+	series, err := tm.dependencies.HandlersManager.Series(ctx, backendName, []string{selector}, endTime.Add(-defaultTimeWindow), endTime, 1, orgID)
+	if err != nil {
+		return false, fmt.Errorf("failed to look up %s series on backend %q: %s", name, backendName, err.Error())
+	}
+	return len(series) > 0, nil
+}
+
+// buildHistogramQuantileQuery builds the histogram_quantile expression for a classic or native histogram
+func buildHistogramQuantileQuery(kind, metric string, matchers, groupBy []string, q float64, rateWindow string) string {
+	selector := metric
+	grouping := append([]string{}, groupBy...)
+	if kind == histogramKindClassic {
+		selector += "_bucket"
+		if !containsLabel(grouping, "le") {
+			grouping = append(grouping, "le")
+		}
+	}
+	if len(matchers) > 0 {
+		selector += "{" + strings.Join(matchers, ", ") + "}"
+	}
+
+	aggregation := "sum"
+	if len(grouping) > 0 {
+		aggregation = fmt.Sprintf("sum by (%s)", strings.Join(grouping, ", "))
+	}
+
+	return fmt.Sprintf("histogram_quantile(%s, %s (rate(%s[%s])))",
+		strconv.FormatFloat(q, 'g', -1, 64), aggregation, selector, rateWindow)
+}
+
+// tagQuantile returns a copy of the metric carrying the quantile label
+func tagQuantile(metric model.Metric, q float64) model.Metric {
+	tagged := metric.Clone()
+	tagged[quantileLabel] = model.LabelValue(strconv.FormatFloat(q, 'g', -1, 64))
+	return tagged
+}
+
+func containsLabel(labels []string, label string) bool {
+	for _, l := range labels {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+package tools
+
+import (
+	"testing"
+)
+
+func TestBuildHistogramQuantileQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		kind     string
+		matchers []string
+		groupBy  []string
+		want     string
+	}{
+		{
+			name: "classic without grouping",
+			kind: histogramKindClassic,
+			want: `histogram_quantile(0.99, sum by (le) (rate(http_request_duration_seconds_bucket[5m])))`,
+		},
+		{
+			name:     "classic with matchers and grouping",
+			kind:     histogramKindClassic,
+			matchers: []string{`job="api"`, `code=~"2.."`},
+			groupBy:  []string{"route"},
+			want:     `histogram_quantile(0.99, sum by (route, le) (rate(http_request_duration_seconds_bucket{job="api", code=~"2.."}[5m])))`,
+		},
+		{
+			name: "native without grouping",
+			kind: histogramKindNative,
+			want: `histogram_quantile(0.99, sum (rate(http_request_duration_seconds[5m])))`,
+		},
+		{
+			name:    "native with grouping",
+			kind:    histogramKindNative,
+			groupBy: []string{"route"},
+			want:    `histogram_quantile(0.99, sum by (route) (rate(http_request_duration_seconds[5m])))`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildHistogramQuantileQuery(tt.kind, "http_request_duration_seconds", tt.matchers, tt.groupBy, 0.99, "5m")
+			if got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
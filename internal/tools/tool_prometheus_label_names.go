@@ -0,0 +1,94 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const defaultLabelsLimit = 100
+
+func (tm *ToolsManager) HandleToolLabelNames(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		Backend  string   `json:"backend,omitempty"`
+		Match    []string `json:"match,omitempty"`
+		Start    string   `json:"start,omitempty"`
+		End      string   `json:"end,omitempty"`
+		Timezone string   `json:"timezone,omitempty"`
+		Query    string   `json:"query,omitempty"`
+		Regex    string   `json:"regex,omitempty"`
+		OrgID    string   `json:"org_id,omitempty"`
+		Limit    int      `json:"limit,omitempty"`
+		Offset   int      `json:"offset,omitempty"`
+	}
+
+	argsBytes, err := json.Marshal(request.Params.Arguments)
+	if err != nil {
+		return mcp.NewToolResultError("failed to marshal arguments: " + err.Error()), nil
+	}
+	if err = json.Unmarshal(argsBytes, &args); err != nil {
+		return mcp.NewToolResultError("failed to parse arguments: " + err.Error()), nil
+	}
+
+	encoder, err := tm.resultEncoder(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	backendName, err := tm.resolveBackend(args.Backend)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	tm.warnIfOrgIDIgnored(backendName, args.OrgID)
+
+	startTime, endTime, err := parseTimeWindow(args.Start, args.End, args.Timezone)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	filter, err := newNameFilter(args.Query, args.Regex)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if args.Limit <= 0 {
+		args.Limit = defaultLabelsLimit
+	}
+	if args.Offset < 0 {
+		args.Offset = 0
+	}
+
+	labelNames, err := tm.dependencies.HandlersManager.LabelNames(ctx, backendName, args.Match, startTime, endTime, args.OrgID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to fetch label names from backend %q: %s", backendName, err.Error())), nil
+	}
+
+	filtered := []string{}
+	for _, name := range labelNames {
+		if filter(name) {
+			filtered = append(filtered, name)
+		}
+	}
+	sort.Strings(filtered)
+
+	totalFiltered := len(filtered)
+	start, end := paginationBounds(totalFiltered, args.Offset, args.Limit)
+
+	encodedResult, err := encoder.Encode(map[string]interface{}{
+		"total_labels": totalFiltered,
+		"returned":     end - start,
+		"offset":       args.Offset,
+		"limit":        args.Limit,
+		"has_more":     end < totalFiltered,
+		"labels":       filtered[start:end],
+	})
+	if err != nil {
+		return mcp.NewToolResultError("failed to marshal result: " + err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Label Names [%s]:\n\n%s", backendName, encodedResult)), nil
+}
@@ -0,0 +1,208 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"prometheus-mcp/internal/promql"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	v1 "github.com/prometheus/client_golang/api/prometheus/v1"
+)
+
+// lintCardinalityLimit is the number of top metrics requested from TSDB stats to detect high-cardinality metrics
+const lintCardinalityLimit = 100
+
+func (tm *ToolsManager) HandleToolLintQuery(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		Backend string `json:"backend,omitempty"`
+		Query   string `json:"query"`
+		OrgID   string `json:"org_id,omitempty"`
+	}
+
+	argsBytes, err := json.Marshal(request.Params.Arguments)
+	if err != nil {
+		return mcp.NewToolResultError("failed to marshal arguments: " + err.Error()), nil
+	}
+	if err = json.Unmarshal(argsBytes, &args); err != nil {
+		return mcp.NewToolResultError("failed to parse arguments: " + err.Error()), nil
+	}
+
+	encoder, err := tm.resultEncoder(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	backendName, err := tm.resolveBackend(args.Backend)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	tm.warnIfOrgIDIgnored(backendName, args.OrgID)
+
+	if args.Query == "" {
+		return mcp.NewToolResultError("query parameter is required"), nil
+	}
+
+	warnings, err := tm.lintQuery(ctx, backendName, args.Query, args.OrgID)
+	if err != nil {
+		var parseErr *promql.ParseError
+		if !errors.As(err, &parseErr) {
+			return mcp.NewToolResultError("failed to lint query: " + err.Error()), nil
+		}
+
+		encodedResult, err := encoder.Encode(map[string]interface{}{
+			"valid":  false,
+			"errors": parseErr.Positions,
+		})
+		if err != nil {
+			return mcp.NewToolResultError("failed to marshal result: " + err.Error()), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Query Lint [%s]:\n\nQuery: %s\n\n%s", backendName, args.Query, encodedResult)), nil
+	}
+
+	encodedResult, err := encoder.Encode(map[string]interface{}{
+		"valid":          true,
+		"total_warnings": len(warnings),
+		"warnings":       warnings,
+	})
+	if err != nil {
+		return mcp.NewToolResultError("failed to marshal result: " + err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Query Lint [%s]:\n\nQuery: %s\n\n%s", backendName, args.Query, encodedResult)), nil
+}
+
+// lintQuery parses the query and checks it against the metadata and cardinality of the metrics it uses.
+// Backend lookups run concurrently, once per metric family, and are best effort: rules lacking data are skipped
+// instead of failing the lint
+func (tm *ToolsManager) lintQuery(ctx context.Context, backendName, query, orgID string) ([]promql.Warning, error) {
+	expr, err := promql.Parse(query)
+	if err != nil {
+		return nil, err
+	}
+
+	families := lintMetadataFamilies(promql.Summarize(expr).Metrics)
+	metadata := make([]map[string]v1.Metadata, len(families))
+
+	var stats v1.TSDBResult
+	var wg sync.WaitGroup
+	for i, candidates := range families {
+		wg.Add(1)
+		go func(i int, candidates []string) {
+			defer wg.Done()
+			result, err := tm.familyMetadata(ctx, backendName, candidates, orgID)
+			if err != nil {
+				tm.dependencies.AppCtx.Logger.Warn("Failed to fetch metadata for lint",
+					"backend", backendName,
+					"metric", candidates[0],
+					"error", err.Error(),
+				)
+				return
+			}
+			metadata[i] = result
+		}(i, candidates)
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		var err error
+		stats, err = tm.dependencies.HandlersManager.TSDB(ctx, backendName, lintCardinalityLimit, orgID)
+		if err != nil {
+			tm.dependencies.AppCtx.Logger.Warn("Failed to fetch TSDB stats for lint",
+				"backend", backendName,
+				"error", err.Error(),
+			)
+		}
+	}()
+	wg.Wait()
+
+	lintCtx := promql.LintContext{
+		MetricTypes: map[string]string{},
+		SeriesCount: map[string]int{},
+	}
+	for _, result := range metadata {
+		for metadataName, entry := range result {
+			lintCtx.MetricTypes[metadataName] = string(entry.Type)
+		}
+	}
+	for _, stat := range stats.SeriesCountByMetricName {
+		lintCtx.SeriesCount[stat.Name] = int(stat.Value)
+	}
+
+	return promql.Lint(expr, lintCtx), nil
+}
+
+// familyMetadata looks the metadata of a metric family up under its candidate names, in order, and stops at the
+// first one known by the metadata endpoint. Targets metadata is only scanned when the metadata endpoint fails
+func (tm *ToolsManager) familyMetadata(ctx context.Context, backendName string, candidates []string, orgID string) (map[string]v1.Metadata, error) {
+	for _, name := range candidates {
+		metadata, err := tm.dependencies.HandlersManager.Metadata(ctx, backendName, name, orgID)
+		if err != nil {
+			return tm.fetchMetricMetadata(ctx, backendName, candidates[0], orgID)
+		}
+
+		result := map[string]v1.Metadata{}
+		for metadataName, entries := range metadata {
+			if len(entries) > 0 {
+				result[metadataName] = entries[0]
+			}
+		}
+		if len(result) > 0 {
+			return result, nil
+		}
+	}
+	return map[string]v1.Metadata{}, nil
+}
+
+// lintMetadataFamilies groups the metrics of a query by family, with the names to look metadata up for.
+// Metadata is usually exposed under the family name, without the suffixes of counters, histograms and summaries,
+// so the base name comes first, followed by the metrics themselves for counters exposed with their _total name
+func lintMetadataFamilies(metrics []string) [][]string {
+	index := map[string]int{}
+	families := [][]string{}
+	for _, metric := range metrics {
+		base := metric
+		for _, suffix := range []string{"_total", "_bucket", "_count", "_sum"} {
+			if trimmed, ok := strings.CutSuffix(metric, suffix); ok {
+				base = trimmed
+				break
+			}
+		}
+
+		i, ok := index[base]
+		if !ok {
+			i = len(families)
+			index[base] = i
+			families = append(families, []string{base})
+		}
+		if !slices.Contains(families[i], metric) {
+			families[i] = append(families[i], metric)
+		}
+	}
+	return families
+}
+
+// formatLintWarnings renders lint warnings as a plain text section appended to query results
+func formatLintWarnings(warnings []promql.Warning) string {
+	if len(warnings) == 0 {
+		return "\n\nLint Warnings: none"
+	}
+
+	var sb strings.Builder
+	sb.WriteString("\n\nLint Warnings:")
+	for _, warning := range warnings {
+		sb.WriteString(fmt.Sprintf("\n- [%s] %s", warning.Rule, warning.Message))
+		if warning.Suggestion != "" {
+			sb.WriteString(fmt.Sprintf("\n  Suggestion: %s", warning.Suggestion))
+		}
+	}
+	return sb.String()
+}
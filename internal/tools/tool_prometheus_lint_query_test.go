@@ -0,0 +1,55 @@
+package tools
+
+import (
+	"reflect"
+	"testing"
+
+	"prometheus-mcp/internal/promql"
+)
+
+func TestLintMetadataFamilies(t *testing.T) {
+	got := lintMetadataFamilies([]string{"http_requests_total", "http_request_duration_seconds_bucket", "http_request_duration_seconds_count", "up"})
+	want := [][]string{
+		{"http_requests", "http_requests_total"},
+		{"http_request_duration_seconds", "http_request_duration_seconds_bucket", "http_request_duration_seconds_count"},
+		{"up"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestFormatLintWarnings(t *testing.T) {
+	tests := []struct {
+		name     string
+		warnings []promql.Warning
+		want     string
+	}{
+		{
+			name: "no warnings",
+			want: "\n\nLint Warnings: none",
+		},
+		{
+			name: "warning with suggestion",
+			warnings: []promql.Warning{
+				{Rule: "rate-on-gauge", Message: "rate over gauge", Suggestion: "deriv(temperature[5m])"},
+			},
+			want: "\n\nLint Warnings:\n- [rate-on-gauge] rate over gauge\n  Suggestion: deriv(temperature[5m])",
+		},
+		{
+			name: "warning without suggestion",
+			warnings: []promql.Warning{
+				{Rule: "quantile-without-le", Message: "recorded series without le"},
+			},
+			want: "\n\nLint Warnings:\n- [quantile-without-le] recorded series without le",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatLintWarnings(tt.warnings); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
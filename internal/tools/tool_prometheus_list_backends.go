@@ -0,0 +1,165 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"prometheus-mcp/api"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	backendProbeTimeout = 5 * time.Second
+	backendProbeQuery   = "vector(1)"
+)
+
+// sensitiveQueryParams are URL query parameters whose values are redacted when showing backend URLs
+var sensitiveQueryParams = []string{"token", "key", "secret", "password", "auth"}
+
+// backendStatus represents a configured backend as reported by prometheus_list_backends
+type backendStatus struct {
+	Name          string   `json:"name"`
+	Type          string   `json:"type"`
+	URL           string   `json:"url"`
+	AuthType      string   `json:"auth_type"`
+	OrgID         string   `json:"org_id"`
+	AvailableOrgs []string `json:"available_orgs"`
+	Initialized   bool     `json:"initialized"`
+	Reachable     bool     `json:"reachable"`
+	LatencyMs     int64    `json:"latency_ms"`
+	Error         string   `json:"error"`
+}
+
+func (tm *ToolsManager) HandleToolListBackends(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := struct {
+		Probe bool `json:"probe"`
+	}{
+		Probe: true,
+	}
+
+	argsBytes, err := json.Marshal(request.Params.Arguments)
+	if err != nil {
+		return mcp.NewToolResultError("failed to marshal arguments: " + err.Error()), nil
+	}
+	if err = json.Unmarshal(argsBytes, &args); err != nil {
+		return mcp.NewToolResultError("failed to parse arguments: " + err.Error()), nil
+	}
+
+	encoder, err := tm.resultEncoder(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	names := make([]string, 0, len(tm.dependencies.AppCtx.Config.Backends))
+	for name := range tm.dependencies.AppCtx.Config.Backends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	backends := make([]backendStatus, len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		cfg := tm.dependencies.AppCtx.Config.Backends[name]
+
+		backends[i] = backendStatus{
+			Name:          name,
+			Type:          cfg.Type,
+			URL:           redactURL(cfg.URL),
+			AuthType:      cfg.Auth.Type,
+			OrgID:         cfg.OrgID,
+			AvailableOrgs: cfg.AvailableOrgs,
+			Initialized:   tm.backendInitialized(name, cfg.Type),
+		}
+		if backends[i].AvailableOrgs == nil {
+			backends[i].AvailableOrgs = []string{}
+		}
+
+		if !args.Probe || !backends[i].Initialized {
+			continue
+		}
+
+		wg.Add(1)
+		go func(status *backendStatus) {
+			defer wg.Done()
+			tm.probeBackend(ctx, status)
+		}(&backends[i])
+	}
+	wg.Wait()
+
+	encodedResult, err := encoder.Encode(map[string]interface{}{
+		"total_backends": len(backends),
+		"probed":         args.Probe,
+		"backends":       backends,
+	})
+	if err != nil {
+		return mcp.NewToolResultError("failed to marshal result: " + err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Backends:\n\n%s", encodedResult)), nil
+}
+
+// backendInitialized reports whether the handlers manager holds a client for the backend
+func (tm *ToolsManager) backendInitialized(name, backendType string) bool {
+	if backendType == api.BackendTypeAlertmanager {
+		_, ok := tm.dependencies.HandlersManager.AlertmanagerClients[name]
+		return ok
+	}
+	_, ok := tm.dependencies.HandlersManager.Clients[name]
+	return ok
+}
+
+// probeBackend performs a cheap request against the backend and records its reachability and latency
+func (tm *ToolsManager) probeBackend(ctx context.Context, status *backendStatus) {
+	ctx, cancel := context.WithTimeout(ctx, backendProbeTimeout)
+	defer cancel()
+
+	var err error
+	startTime := time.Now()
+	if status.Type == api.BackendTypeAlertmanager {
+		_, err = tm.dependencies.HandlersManager.AlertmanagerStatus(ctx, status.Name, "")
+	} else {
+		_, err = tm.dependencies.HandlersManager.Query(ctx, status.Name, backendProbeQuery, startTime, "")
+	}
+	status.LatencyMs = time.Since(startTime).Milliseconds()
+
+	if err != nil {
+		status.Error = err.Error()
+		return
+	}
+	status.Reachable = true
+}
+
+// redactURL removes credentials from a backend URL: user info and sensitive query parameter values
+func redactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "<invalid url>"
+	}
+
+	u.User = nil
+
+	query := u.Query()
+	redacted := false
+	for name := range query {
+		lowerName := strings.ToLower(name)
+		for _, sensitive := range sensitiveQueryParams {
+			if strings.Contains(lowerName, sensitive) {
+				query.Set(name, "REDACTED")
+				redacted = true
+				break
+			}
+		}
+	}
+	if redacted {
+		u.RawQuery = query.Encode()
+	}
+
+	return u.String()
+}
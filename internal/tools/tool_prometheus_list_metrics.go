@@ -4,22 +4,41 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
-	"github.com/alpkeskin/gotoon"
 	"github.com/mark3labs/mcp-go/mcp"
+	v1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
 )
 
-const defaultMetricsLimit = 100
+const (
+	defaultMetricsLimit    = 100
+	defaultMetricsLookback = "1h"
+)
+
+// Metric sort orders
+const (
+	metricsSortName     = "name"
+	metricsSortNameDesc = "name_desc"
+	metricsSortSeries   = "series"
+)
 
 func (tm *ToolsManager) HandleToolListMetrics(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	var args struct {
-		Backend string `json:"backend,omitempty"`
-		Query   string `json:"query,omitempty"`
-		OrgID   string `json:"org_id,omitempty"`
-		Limit   int    `json:"limit,omitempty"`
-		Offset  int    `json:"offset,omitempty"`
+		Backend  string   `json:"backend,omitempty"`
+		Query    string   `json:"query,omitempty"`
+		Regex    string   `json:"regex,omitempty"`
+		Search   string   `json:"search,omitempty"`
+		Match    []string `json:"match,omitempty"`
+		Lookback string   `json:"lookback,omitempty"`
+		Sort     string   `json:"sort,omitempty"`
+		OrgID    string   `json:"org_id,omitempty"`
+		Limit    int      `json:"limit,omitempty"`
+		Offset   int      `json:"offset,omitempty"`
+
+		IncludeMetadata bool `json:"include_metadata,omitempty"`
 	}
 
 	argsBytes, err := json.Marshal(request.Params.Arguments)
@@ -30,18 +49,18 @@ func (tm *ToolsManager) HandleToolListMetrics(ctx context.Context, request mcp.C
 		return mcp.NewToolResultError("failed to parse arguments: " + err.Error()), nil
 	}
 
-	backendName, err := tm.resolveBackend(args.Backend)
+	encoder, err := tm.resultEncoder(request)
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	tm.warnIfOrgIDIgnored(backendName, args.OrgID)
-
-	client, err := tm.dependencies.HandlersManager.GetClient(backendName)
+	backendName, err := tm.resolveBackend(args.Backend)
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
+	tm.warnIfOrgIDIgnored(backendName, args.OrgID)
+
 	if args.Limit <= 0 {
 		args.Limit = defaultMetricsLimit
 	}
@@ -49,59 +68,178 @@ func (tm *ToolsManager) HandleToolListMetrics(ctx context.Context, request mcp.C
 		args.Offset = 0
 	}
 
-	if args.Query != "" {
-		if _, err := filepath.Match(args.Query, ""); err != nil {
-			return mcp.NewToolResultError("invalid glob pattern: " + err.Error()), nil
-		}
+	filter, err := newNameFilter(args.Query, args.Regex)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
+	search := strings.ToLower(args.Search)
 
-	if args.OrgID != "" {
-		ctx = context.WithValue(ctx, "org_id", args.OrgID)
+	if args.Lookback == "" {
+		args.Lookback = defaultMetricsLookback
+	}
+	lookback, err := model.ParseDuration(args.Lookback)
+	if err != nil || lookback <= 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("invalid lookback %q, use a positive duration (e.g., '30m', '6h', '7d')", args.Lookback)), nil
 	}
 
-	metricNames, warnings, err := client.LabelValues(ctx, "__name__", []string{}, time.Now().Add(-time.Hour), time.Now())
+	if args.Sort == "" {
+		args.Sort = metricsSortName
+	}
+	switch args.Sort {
+	case metricsSortName, metricsSortNameDesc, metricsSortSeries:
+	default:
+		return mcp.NewToolResultError(fmt.Sprintf("invalid sort %q, use one of: %s, %s, %s", args.Sort, metricsSortName, metricsSortNameDesc, metricsSortSeries)), nil
+	}
+
+	endTime := time.Now()
+	startTime := endTime.Add(-time.Duration(lookback))
+
+	metricNames, err := tm.dependencies.HandlersManager.LabelValues(ctx, backendName, model.MetricNameLabel, args.Match, startTime, endTime, args.OrgID)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("failed to fetch metrics list from backend %q: %s", backendName, err.Error())), nil
 	}
 
-	if len(warnings) > 0 {
-		tm.dependencies.AppCtx.Logger.Warn("List metrics warnings", "backend", backendName, "warnings", warnings)
+	filtered := []string{}
+	for _, value := range metricNames {
+		name := string(value)
+		if !filter(name) {
+			continue
+		}
+		if search != "" && !strings.Contains(strings.ToLower(name), search) {
+			continue
+		}
+		filtered = append(filtered, name)
 	}
 
-	var filtered []string
-	for _, name := range metricNames {
-		if args.Query == "" {
-			filtered = append(filtered, string(name))
-		} else if matched, _ := filepath.Match(args.Query, string(name)); matched {
-			filtered = append(filtered, string(name))
+	var seriesCounts map[string]int
+	if args.Sort == metricsSortSeries {
+		if len(args.Match) > 0 {
+			seriesCounts, err = tm.metricSeriesCounts(ctx, backendName, metricSeriesCountQuery(args.Match, lookback), endTime, args.OrgID)
+		} else {
+			seriesCounts, err = tm.headSeriesCounts(ctx, backendName, len(metricNames), args.OrgID)
+		}
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to count series on backend %q: %s", backendName, err.Error())), nil
 		}
 	}
+	sortMetricNames(filtered, args.Sort, seriesCounts)
 
 	totalFiltered := len(filtered)
-	start := args.Offset
-	end := args.Offset + args.Limit
-
-	if start > totalFiltered {
-		start = totalFiltered
-	}
-	if end > totalFiltered {
-		end = totalFiltered
-	}
+	start, end := paginationBounds(totalFiltered, args.Offset, args.Limit)
 
 	paginatedResult := filtered[start:end]
 	hasMore := end < totalFiltered
 
-	resultTOON, err := gotoon.Encode(map[string]interface{}{
+	var metrics interface{} = paginatedResult
+	if args.IncludeMetadata || seriesCounts != nil {
+		var metadata map[string]v1.Metadata
+		if args.IncludeMetadata {
+			metadata, err = tm.fetchMetricMetadata(ctx, backendName, "", args.OrgID)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to fetch metadata from backend %q: %s", backendName, err.Error())), nil
+			}
+		}
+
+		entries := make([]map[string]interface{}, 0, len(paginatedResult))
+		for _, name := range paginatedResult {
+			entry := map[string]interface{}{"name": name}
+			if args.IncludeMetadata {
+				entry = metadataEntry(name, metadata[name])
+			}
+			if seriesCounts != nil {
+				entry["series"] = seriesCounts[name]
+			}
+			entries = append(entries, entry)
+		}
+		metrics = entries
+	}
+
+	encodedResult, err := encoder.Encode(map[string]interface{}{
 		"total_metrics": totalFiltered,
 		"returned":      len(paginatedResult),
 		"offset":        args.Offset,
 		"limit":         args.Limit,
 		"has_more":      hasMore,
-		"metrics":       paginatedResult,
+		"lookback":      args.Lookback,
+		"sorted_by":     args.Sort,
+		"metrics":       metrics,
 	})
 	if err != nil {
 		return mcp.NewToolResultError("failed to marshal result: " + err.Error()), nil
 	}
 
-	return mcp.NewToolResultText(fmt.Sprintf("Available Metrics [%s]:\n\n%s", backendName, resultTOON)), nil
+	return mcp.NewToolResultText(fmt.Sprintf("Available Metrics [%s]:\n\n%s", backendName, encodedResult)), nil
+}
+
+// metricSeriesCounts runs a series count query and returns the number of series of each metric name
+func (tm *ToolsManager) metricSeriesCounts(ctx context.Context, backendName string, query string, at time.Time, orgID string) (map[string]int, error) {
+	counts := map[string]int{}
+	if query == "" {
+		return counts, nil
+	}
+
+	result, err := tm.dependencies.HandlersManager.Query(ctx, backendName, query, at, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	vector, ok := result.(model.Vector)
+	if !ok {
+		return nil, fmt.Errorf("unexpected result type %T", result)
+	}
+
+	for _, sample := range vector {
+		counts[string(sample.Metric[model.MetricNameLabel])] = int(sample.Value)
+	}
+	return counts, nil
+}
+
+// headSeriesCounts returns the number of series of each metric name in the head block, from the TSDB status.
+// It reads index statistics instead of scanning samples, so it stays cheap with thousands of metrics
+func (tm *ToolsManager) headSeriesCounts(ctx context.Context, backendName string, limit int, orgID string) (map[string]int, error) {
+	counts := map[string]int{}
+	if limit == 0 {
+		return counts, nil
+	}
+
+	stats, err := tm.dependencies.HandlersManager.TSDB(ctx, backendName, limit, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, stat := range stats.SeriesCountByMetricName {
+		counts[stat.Name] = int(stat.Value)
+	}
+	return counts, nil
+}
+
+// metricSeriesCountQuery builds the query counting the series of each metric with samples in the lookback
+// window, scoped to the match selectors. last_over_time keeps the metric name, so series can be grouped by it
+func metricSeriesCountQuery(matches []string, lookback model.Duration) string {
+	if len(matches) == 0 {
+		return ""
+	}
+
+	windows := make([]string, 0, len(matches))
+	for _, selector := range matches {
+		windows = append(windows, fmt.Sprintf("last_over_time(%s[%s])", selector, lookback))
+	}
+	return fmt.Sprintf("count by (__name__) (%s)", strings.Join(windows, " or "))
+}
+
+// sortMetricNames sorts metric names in place. Sorting by series puts the biggest metrics first, by name on ties
+func sortMetricNames(names []string, order string, seriesCounts map[string]int) {
+	switch order {
+	case metricsSortNameDesc:
+		sort.Sort(sort.Reverse(sort.StringSlice(names)))
+	case metricsSortSeries:
+		sort.Slice(names, func(i, j int) bool {
+			if seriesCounts[names[i]] != seriesCounts[names[j]] {
+				return seriesCounts[names[i]] > seriesCounts[names[j]]
+			}
+			return names[i] < names[j]
+		})
+	default:
+		sort.Strings(names)
+	}
 }
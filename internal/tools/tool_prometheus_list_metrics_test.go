@@ -0,0 +1,57 @@
+package tools
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+)
+
+func TestSortMetricNames(t *testing.T) {
+	seriesCounts := map[string]int{"b_metric": 10, "c_metric": 500, "a_metric": 10}
+
+	tests := []struct {
+		order string
+		want  []string
+	}{
+		{metricsSortName, []string{"a_metric", "b_metric", "c_metric", "d_metric"}},
+		{metricsSortNameDesc, []string{"d_metric", "c_metric", "b_metric", "a_metric"}},
+		// Ties are sorted by name, metrics without active series go last
+		{metricsSortSeries, []string{"c_metric", "a_metric", "b_metric", "d_metric"}},
+	}
+
+	for _, tt := range tests {
+		names := []string{"c_metric", "a_metric", "d_metric", "b_metric"}
+		sortMetricNames(names, tt.order, seriesCounts)
+		if !reflect.DeepEqual(names, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.order, names, tt.want)
+		}
+	}
+}
+
+func TestMetricSeriesCountQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		matches []string
+		want    string
+	}{
+		{
+			name:    "scoped to the match selectors",
+			matches: []string{`{job="api"}`, `{namespace="payments"}`},
+			want:    `count by (__name__) (last_over_time({job="api"}[6h]) or last_over_time({namespace="payments"}[6h]))`,
+		},
+		{
+			name: "nothing to count",
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := metricSeriesCountQuery(tt.matches, model.Duration(6*time.Hour)); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
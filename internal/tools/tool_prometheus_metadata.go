@@ -0,0 +1,147 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	v1 "github.com/prometheus/client_golang/api/prometheus/v1"
+)
+
+func (tm *ToolsManager) HandleToolMetadata(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		Backend string `json:"backend,omitempty"`
+		Metric  string `json:"metric,omitempty"`
+		Query   string `json:"query,omitempty"`
+		OrgID   string `json:"org_id,omitempty"`
+		Limit   int    `json:"limit,omitempty"`
+		Offset  int    `json:"offset,omitempty"`
+	}
+
+	argsBytes, err := json.Marshal(request.Params.Arguments)
+	if err != nil {
+		return mcp.NewToolResultError("failed to marshal arguments: " + err.Error()), nil
+	}
+	if err = json.Unmarshal(argsBytes, &args); err != nil {
+		return mcp.NewToolResultError("failed to parse arguments: " + err.Error()), nil
+	}
+
+	encoder, err := tm.resultEncoder(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	backendName, err := tm.resolveBackend(args.Backend)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	tm.warnIfOrgIDIgnored(backendName, args.OrgID)
+
+	filter, err := newNameFilter(args.Query, "")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if args.Limit <= 0 {
+		args.Limit = defaultMetricsLimit
+	}
+	if args.Offset < 0 {
+		args.Offset = 0
+	}
+
+	metadata, err := tm.fetchMetricMetadata(ctx, backendName, args.Metric, args.OrgID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to fetch metadata from backend %q: %s", backendName, err.Error())), nil
+	}
+
+	names := make([]string, 0, len(metadata))
+	for name := range metadata {
+		if filter(name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	totalFiltered := len(names)
+	start, end := paginationBounds(totalFiltered, args.Offset, args.Limit)
+
+	entries := make([]map[string]interface{}, 0, end-start)
+	for _, name := range names[start:end] {
+		entries = append(entries, metadataEntry(name, metadata[name]))
+	}
+
+	encodedResult, err := encoder.Encode(map[string]interface{}{
+		"total_metrics": totalFiltered,
+		"returned":      len(entries),
+		"offset":        args.Offset,
+		"limit":         args.Limit,
+		"has_more":      end < totalFiltered,
+		"metadata":      entries,
+	})
+	if err != nil {
+		return mcp.NewToolResultError("failed to marshal result: " + err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Metric Metadata [%s]:\n\n%s", backendName, encodedResult)), nil
+}
+
+// fetchMetricMetadata returns the metadata of every metric (or only the given one), keyed by metric name.
+// Some backends do not implement the metadata endpoint, so targets metadata is used as fallback
+func (tm *ToolsManager) fetchMetricMetadata(ctx context.Context, backendName, metric, orgID string) (map[string]v1.Metadata, error) {
+	result := map[string]v1.Metadata{}
+
+	metadata, err := tm.dependencies.HandlersManager.Metadata(ctx, backendName, metric, orgID)
+	if err == nil && len(metadata) > 0 {
+		for name, entries := range metadata {
+			if len(entries) > 0 {
+				result[name] = entries[0]
+			}
+		}
+		return result, nil
+	}
+
+	if err != nil {
+		tm.dependencies.AppCtx.Logger.Warn("Metadata endpoint failed, falling back to targets metadata",
+			"backend", backendName,
+			"error", err.Error(),
+		)
+	}
+
+	targetsMetadata, targetsErr := tm.dependencies.HandlersManager.TargetsMetadata(ctx, backendName, metric, orgID)
+	if targetsErr != nil {
+		if err != nil {
+			return nil, err
+		}
+		return nil, targetsErr
+	}
+
+	for _, entry := range targetsMetadata {
+		// The metric name is omitted from the response when it was requested explicitly
+		name := entry.Metric
+		if name == "" {
+			name = metric
+		}
+		if _, ok := result[name]; ok {
+			continue
+		}
+		result[name] = v1.Metadata{
+			Type: entry.Type,
+			Help: entry.Help,
+			Unit: entry.Unit,
+		}
+	}
+
+	return result, nil
+}
+
+func metadataEntry(name string, metadata v1.Metadata) map[string]interface{} {
+	return map[string]interface{}{
+		"name": name,
+		"type": string(metadata.Type),
+		"help": metadata.Help,
+		"unit": metadata.Unit,
+	}
+}
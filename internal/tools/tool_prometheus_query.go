@@ -4,18 +4,24 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
-	"github.com/alpkeskin/gotoon"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
 func (tm *ToolsManager) HandleToolQuery(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	var args struct {
-		Backend string `json:"backend,omitempty"`
-		Query   string `json:"query"`
-		Time    string `json:"time,omitempty"`
-		OrgID   string `json:"org_id,omitempty"`
+		Backend    string   `json:"backend,omitempty"`
+		Backends   []string `json:"backends,omitempty"`
+		Query      string   `json:"query"`
+		Time       string   `json:"time,omitempty"`
+		Timezone   string   `json:"timezone,omitempty"`
+		OrgID      string   `json:"org_id,omitempty"`
+		Limit      int      `json:"limit,omitempty"`
+		Offset     int      `json:"offset,omitempty"`
+		MaxSamples int      `json:"max_samples,omitempty"`
+		Lint       bool     `json:"lint,omitempty"`
 	}
 
 	argsBytes, err := json.Marshal(request.Params.Arguments)
@@ -26,37 +32,87 @@ func (tm *ToolsManager) HandleToolQuery(ctx context.Context, request mcp.CallToo
 		return mcp.NewToolResultError("failed to parse arguments: " + err.Error()), nil
 	}
 
-	backendName, err := tm.resolveBackend(args.Backend)
+	encoder, err := tm.resultEncoder(request)
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	tm.warnIfOrgIDIgnored(backendName, args.OrgID)
+	backends := []string{args.Backend}
+	if len(args.Backends) > 0 {
+		backends, err = tm.resolveBackends(args.Backends)
+	} else {
+		backends[0], err = tm.resolveBackend(args.Backend)
+	}
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	backendName := backends[0]
+
+	for _, name := range backends {
+		tm.warnIfOrgIDIgnored(name, args.OrgID)
+	}
 
 	if args.Query == "" {
 		return mcp.NewToolResultError("query parameter is required"), nil
 	}
+	if result := tm.preflightQuery(args.Query); result != nil {
+		return result, nil
+	}
+
+	loc, err := loadTimezone(args.Timezone)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
-	var timestamp time.Time
+	timestamp := time.Now().In(loc)
 	if args.Time != "" {
-		timestamp, err = time.Parse(time.RFC3339, args.Time)
+		timestamp, err = parseTime(args.Time, time.Now(), loc)
 		if err != nil {
-			return mcp.NewToolResultError("invalid time format, use RFC3339: " + err.Error()), nil
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+	}
+
+	var result interface{}
+	backendErrors := map[string]string{}
+	if len(args.Backends) > 0 {
+		result, backendErrors = tm.fanOut(ctx, backends, func(ctx context.Context, name string) (interface{}, error) {
+			return tm.dependencies.HandlersManager.Query(ctx, name, args.Query, timestamp, args.OrgID)
+		})
+		if failure := fanOutFailure(backends, backendErrors); failure != nil {
+			return failure, nil
 		}
 	} else {
-		timestamp = time.Now()
+		result, err = tm.dependencies.HandlersManager.Query(ctx, backendName, args.Query, timestamp, args.OrgID)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to execute query on backend %q: %s", backendName, err.Error())), nil
+		}
 	}
 
-	result, err := tm.dependencies.HandlersManager.Query(ctx, backendName, args.Query, timestamp, args.OrgID)
+	result, pagination := applyResultLimits(result, tm.limitsFor(backends, args.Limit, args.Offset, args.MaxSamples))
+
+	encodedResult, err := encoder.Encode(renderHistograms(result))
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("failed to execute query on backend %q: %s", backendName, err.Error())), nil
+		return mcp.NewToolResultError("failed to marshal result: " + err.Error()), nil
 	}
 
-	resultTOON, err := gotoon.Encode(result)
+	text := fmt.Sprintf("Query Results [%s]:\n\nQuery: %s\nTimestamp: %s\n\nResults:\n%s",
+		strings.Join(backends, ", "), args.Query, timestamp.Format(time.RFC3339), encodedResult)
+	text += formatBackendErrors(backendErrors)
+
+	paginationText, err := formatPagination(encoder, pagination)
 	if err != nil {
-		return mcp.NewToolResultError("failed to marshal result: " + err.Error()), nil
+		return mcp.NewToolResultError("failed to marshal pagination: " + err.Error()), nil
+	}
+	text += paginationText
+
+	if args.Lint {
+		warnings, err := tm.lintQuery(ctx, backendName, args.Query, args.OrgID)
+		if err != nil {
+			tm.dependencies.AppCtx.Logger.Warn("Failed to lint query", "backend", backendName, "error", err.Error())
+		} else {
+			text += formatLintWarnings(warnings)
+		}
 	}
 
-	return mcp.NewToolResultText(fmt.Sprintf("Query Results [%s]:\n\nQuery: %s\nTimestamp: %s\n\nResults:\n%s",
-		backendName, args.Query, timestamp.Format(time.RFC3339), resultTOON)), nil
+	return mcp.NewToolResultText(text), nil
 }
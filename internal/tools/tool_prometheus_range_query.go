@@ -4,20 +4,47 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
+	"sort"
+	"strings"
 	"time"
 
-	"github.com/alpkeskin/gotoon"
+	"prometheus-mcp/internal/chart"
+
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/prometheus/common/model"
+)
+
+const (
+	defaultSummarySortBy = "max"
+
+	// summaryDigits is the number of significant digits of the statistics of summarised series
+	summaryDigits = 6
 )
 
 func (tm *ToolsManager) HandleToolRangeQuery(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	var args struct {
-		Backend string `json:"backend,omitempty"`
-		Query   string `json:"query"`
-		Start   string `json:"start"`
-		End     string `json:"end"`
-		Step    string `json:"step,omitempty"`
-		OrgID   string `json:"org_id,omitempty"`
+		Backend     string   `json:"backend,omitempty"`
+		Backends    []string `json:"backends,omitempty"`
+		Query       string   `json:"query"`
+		Start       string   `json:"start"`
+		End         string   `json:"end"`
+		Timezone    string   `json:"timezone,omitempty"`
+		Step        string   `json:"step,omitempty"`
+		MaxPoints   int      `json:"max_points,omitempty"`
+		OrgID       string   `json:"org_id,omitempty"`
+		Limit       int      `json:"limit,omitempty"`
+		Offset      int      `json:"offset,omitempty"`
+		MaxSamples  int      `json:"max_samples,omitempty"`
+		Summary     bool     `json:"summary,omitempty"`
+		TopN        int      `json:"top_n,omitempty"`
+		SortBy      string   `json:"sort_by,omitempty"`
+		Chart       string   `json:"chart,omitempty"`
+		ChartSeries int      `json:"chart_series,omitempty"`
+		ChartUnit   string   `json:"chart_unit,omitempty"`
+
+		Sparkline      bool `json:"sparkline,omitempty"`
+		SparklineWidth int  `json:"sparkline_width,omitempty"`
 	}
 
 	argsBytes, err := json.Marshal(request.Params.Arguments)
@@ -28,16 +55,32 @@ func (tm *ToolsManager) HandleToolRangeQuery(ctx context.Context, request mcp.Ca
 		return mcp.NewToolResultError("failed to parse arguments: " + err.Error()), nil
 	}
 
-	backendName, err := tm.resolveBackend(args.Backend)
+	encoder, err := tm.resultEncoder(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	backends := []string{args.Backend}
+	if len(args.Backends) > 0 {
+		backends, err = tm.resolveBackends(args.Backends)
+	} else {
+		backends[0], err = tm.resolveBackend(args.Backend)
+	}
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
+	backendName := backends[0]
 
-	tm.warnIfOrgIDIgnored(backendName, args.OrgID)
+	for _, name := range backends {
+		tm.warnIfOrgIDIgnored(name, args.OrgID)
+	}
 
 	if args.Query == "" {
 		return mcp.NewToolResultError("query parameter is required"), nil
 	}
+	if result := tm.preflightQuery(args.Query); result != nil {
+		return result, nil
+	}
 	if args.Start == "" {
 		return mcp.NewToolResultError("start parameter is required"), nil
 	}
@@ -45,35 +88,162 @@ func (tm *ToolsManager) HandleToolRangeQuery(ctx context.Context, request mcp.Ca
 		return mcp.NewToolResultError("end parameter is required"), nil
 	}
 
-	startTime, err := time.Parse(time.RFC3339, args.Start)
+	startTime, endTime, err := parseTimeWindow(args.Start, args.End, args.Timezone)
 	if err != nil {
-		return mcp.NewToolResultError("invalid start time format, use RFC3339: " + err.Error()), nil
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	var endTime time.Time
-	endTime, err = time.Parse(time.RFC3339, args.End)
+	step, stepMode, err := resolveStep(args.Step, tm.maxPoints(args.MaxPoints), startTime, endTime)
 	if err != nil {
-		return mcp.NewToolResultError("invalid end time format, use RFC3339: " + err.Error()), nil
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	step := time.Minute
-	if args.Step != "" {
-		step, err = time.ParseDuration(args.Step)
+	if args.SortBy == "" {
+		args.SortBy = defaultSummarySortBy
+	}
+	if _, err := (seriesStats{}).stat(args.SortBy); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("invalid sort_by, use one of: %s", strings.Join(seriesStatNames, ", "))), nil
+	}
+	if args.TopN < 0 {
+		args.TopN = 0
+	}
+	if args.Summary && args.Sparkline {
+		return mcp.NewToolResultError("summary and sparkline modes cannot be combined"), nil
+	}
+	if args.SparklineWidth <= 0 {
+		args.SparklineWidth = defaultSparklineWidth
+	}
+	args.SparklineWidth = min(args.SparklineWidth, maxSparklineWidth)
+	if _, ok := chartMimeTypes[args.Chart]; args.Chart != "" && !ok {
+		return mcp.NewToolResultError(fmt.Sprintf("invalid chart format %q, use 'png' or 'svg'", args.Chart)), nil
+	}
+
+	var result interface{}
+	backendErrors := map[string]string{}
+	if len(args.Backends) > 0 {
+		result, backendErrors = tm.fanOut(ctx, backends, func(ctx context.Context, name string) (interface{}, error) {
+			return tm.dependencies.HandlersManager.QueryRange(ctx, name, args.Query, startTime, endTime, step, args.OrgID)
+		})
+		if failure := fanOutFailure(backends, backendErrors); failure != nil {
+			return failure, nil
+		}
+	} else {
+		result, err = tm.dependencies.HandlersManager.QueryRange(ctx, backendName, args.Query, startTime, endTime, step, args.OrgID)
 		if err != nil {
-			return mcp.NewToolResultError("invalid step duration: " + err.Error()), nil
+			return mcp.NewToolResultError(fmt.Sprintf("failed to execute range query on backend %q: %s", backendName, err.Error())), nil
 		}
 	}
 
-	result, err := tm.dependencies.HandlersManager.QueryRange(ctx, backendName, args.Query, startTime, endTime, step, args.OrgID)
+	limits := tm.limitsFor(backends, args.Limit, args.Offset, args.MaxSamples)
+	if args.Sparkline {
+		// Samples are not returned in sparkline mode, only the series count is limited
+		limits.MaxSamples = 0
+	}
+
+	// Summary mode ranks every series before paginating the ranking, charts then draw the ranked page
+	var pagination map[string]interface{}
+	matrix, isMatrix := result.(model.Matrix)
+	if isMatrix && args.Summary {
+		result, matrix, pagination = summarizeMatrix(matrix, args.SortBy, args.TopN, limits)
+	} else {
+		result, pagination = applyResultLimits(result, limits)
+		matrix, isMatrix = result.(model.Matrix)
+	}
+
+	var encodedResult string
+	if isMatrix && args.Sparkline {
+		encodedResult = renderSparklines(matrix, startTime, endTime, step, args.SparklineWidth)
+	} else {
+		encodedResult, err = encoder.Encode(renderHistograms(result))
+		if err != nil {
+			return mcp.NewToolResultError("failed to marshal result: " + err.Error()), nil
+		}
+	}
+
+	text := fmt.Sprintf("Range Query Results [%s]:\n\nQuery: %s\nStart: %s\nEnd: %s\nStep: %s (%s)\n\nResults:\n%s",
+		strings.Join(backends, ", "), args.Query, startTime.Format(time.RFC3339), endTime.Format(time.RFC3339), step.String(), stepMode, encodedResult)
+	text += formatBackendErrors(backendErrors)
+
+	paginationText, err := formatPagination(encoder, pagination)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("failed to execute range query on backend %q: %s", backendName, err.Error())), nil
+		return mcp.NewToolResultError("failed to marshal pagination: " + err.Error()), nil
+	}
+	text += paginationText
+
+	if args.Chart == "" {
+		return mcp.NewToolResultText(text), nil
+	}
+	if !isMatrix {
+		text += "\n\nChart not rendered: the result is not a range vector"
+		return mcp.NewToolResultText(text), nil
 	}
 
-	resultTOON, err := gotoon.Encode(result)
+	if args.ChartUnit == "" {
+		args.ChartUnit = inferChartUnit(args.Query)
+	}
+	loc, err := loadTimezone(args.Timezone)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	chartOptions := chart.Options{Title: args.Query, Unit: args.ChartUnit, MaxSeries: args.ChartSeries}
+	imageResult, err := chartResult(text, matrix, args.Chart, chartOptions, loc)
 	if err != nil {
-		return mcp.NewToolResultError("failed to marshal result: " + err.Error()), nil
+		text += fmt.Sprintf("\n\nChart not rendered: %s", err.Error())
+		return mcp.NewToolResultText(text), nil
+	}
+
+	return imageResult, nil
+}
+
+// summarizeMatrix replaces the samples of every series by their statistics, ranked by the given statistic,
+// and keeps the requested page of the ranking. The raw samples are only kept for the top N series, within
+// the samples budget. It also returns the series of the page, in rank order
+func summarizeMatrix(matrix model.Matrix, sortBy string, topN int, limits resultLimits) (map[string]interface{}, model.Matrix, map[string]interface{}) {
+	type rankedSeries struct {
+		stream *model.SampleStream
+		stats  seriesStats
+		rank   float64
 	}
 
-	return mcp.NewToolResultText(fmt.Sprintf("Range Query Results [%s]:\n\nQuery: %s\nStart: %s\nEnd: %s\nStep: %s\n\nResults:\n%s",
-		backendName, args.Query, startTime.Format(time.RFC3339), endTime.Format(time.RFC3339), step.String(), resultTOON)), nil
+	ranked := make([]rankedSeries, 0, len(matrix))
+	for _, stream := range matrix {
+		stats := computeSeriesStats(stream.Values)
+		rank, _ := stats.stat(sortBy)
+		ranked = append(ranked, rankedSeries{stream: stream, stats: stats, rank: rank})
+	}
+
+	// Biggest first, series without samples last
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := ranked[i].rank, ranked[j].rank
+		if math.IsNaN(a) != math.IsNaN(b) {
+			return !math.IsNaN(a)
+		}
+		if a != b {
+			return a > b
+		}
+		return ranked[i].stream.Metric.String() < ranked[j].stream.Metric.String()
+	})
+
+	start, end := resultPageBounds(len(ranked), limits)
+	rows := make([]map[string]interface{}, 0, end-start)
+	page := make(model.Matrix, 0, end-start)
+	for _, series := range ranked[start:end] {
+		rows = append(rows, series.stats.summaryRow(series.stream.Metric.String(), summaryDigits))
+		page = append(page, series.stream)
+	}
+
+	top := model.Matrix{}
+	for i := 0; i < topN && i < len(ranked); i++ {
+		top = append(top, ranked[i].stream)
+	}
+	raw, droppedSeries, droppedSamples := applySamplesBudget(top, limits.MaxSamples)
+
+	summary := map[string]interface{}{
+		"total_series": len(matrix),
+		"sorted_by":    sortBy,
+		"series":       rows,
+		"raw_top_n":    renderHistograms(raw),
+	}
+	return summary, page, paginationInfo(len(ranked), limits, len(rows), droppedSeries, droppedSamples)
 }
@@ -0,0 +1,60 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/prometheus/common/model"
+)
+
+func TestSummarizeMatrix(t *testing.T) {
+	// Label order is the reverse of the ranking by max
+	matrix := model.Matrix{stream("a", 2), stream("b", 4), stream("c", 6), stream("d", 8)}
+
+	summary, page, pagination := summarizeMatrix(matrix, "max", 3, resultLimits{Limit: 2, MaxSamples: 15})
+
+	// The whole matrix is ranked before paginating
+	if got := summary["total_series"]; got != 4 {
+		t.Errorf("total_series = %v, want 4", got)
+	}
+	if len(page) != 2 || page[0].Metric["job"] != "d" || page[1].Metric["job"] != "c" {
+		t.Errorf("page = %v, want jobs d and c", page)
+	}
+	if pagination["has_more"] != true || pagination["next_offset"] != 2 {
+		t.Errorf("pagination = %v, want more series from offset 2", pagination)
+	}
+
+	// The top 3 series hold 18 samples, the third one does not fit in the budget
+	raw := summary["raw_top_n"].(model.Matrix)
+	if len(raw) != 2 || raw[0].Metric["job"] != "d" || raw[1].Metric["job"] != "c" {
+		t.Errorf("raw_top_n = %v, want jobs d and c", raw)
+	}
+	if pagination["dropped_series"] != 1 || pagination["dropped_samples"] != 4 {
+		t.Errorf("dropped = %v series and %v samples, want 1 and 4", pagination["dropped_series"], pagination["dropped_samples"])
+	}
+}
+
+func TestSummarizeMatrixRendersHistograms(t *testing.T) {
+	histogram := &model.SampleStream{
+		Metric: model.Metric{"job": "native"},
+		Histograms: []model.SampleHistogramPair{{
+			Timestamp: 1000,
+			Histogram: &model.SampleHistogram{
+				Count:   10,
+				Sum:     15,
+				Buckets: model.HistogramBuckets{{Boundaries: 0, Lower: 1, Upper: 2, Count: 10}},
+			},
+		}},
+	}
+	matrix := model.Matrix{stream("a", 2), histogram}
+
+	summary, _, _ := summarizeMatrix(matrix, "max", 2, resultLimits{})
+
+	// Native histograms are encoded as in the non-summary results
+	raw, ok := summary["raw_top_n"].([]map[string]interface{})
+	if !ok {
+		t.Fatalf("raw_top_n = %T, want rendered histograms", summary["raw_top_n"])
+	}
+	if len(raw) != 2 || raw[1]["histograms"] == nil {
+		t.Errorf("raw_top_n = %v, want the native series rendered", raw)
+	}
+}
@@ -0,0 +1,168 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	v1 "github.com/prometheus/client_golang/api/prometheus/v1"
+)
+
+const defaultRulesLimit = 50
+
+func (tm *ToolsManager) HandleToolRules(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		Backend string `json:"backend,omitempty"`
+		Type    string `json:"type,omitempty"`
+		Group   string `json:"group,omitempty"`
+		Name    string `json:"name,omitempty"`
+		State   string `json:"state,omitempty"`
+		Health  string `json:"health,omitempty"`
+		OrgID   string `json:"org_id,omitempty"`
+		Limit   int    `json:"limit,omitempty"`
+		Offset  int    `json:"offset,omitempty"`
+	}
+
+	argsBytes, err := json.Marshal(request.Params.Arguments)
+	if err != nil {
+		return mcp.NewToolResultError("failed to marshal arguments: " + err.Error()), nil
+	}
+	if err = json.Unmarshal(argsBytes, &args); err != nil {
+		return mcp.NewToolResultError("failed to parse arguments: " + err.Error()), nil
+	}
+
+	encoder, err := tm.resultEncoder(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	backendName, err := tm.resolveBackend(args.Backend)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	tm.warnIfOrgIDIgnored(backendName, args.OrgID)
+
+	for _, pattern := range []string{args.Group, args.Name} {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return mcp.NewToolResultError("invalid glob pattern: " + err.Error()), nil
+		}
+	}
+
+	// Only alerting rules have a state, so filtering by it implies the rule type
+	if args.State != "" {
+		if args.Type == string(v1.RuleTypeRecording) {
+			return mcp.NewToolResultError("state filter only applies to alerting rules"), nil
+		}
+		args.Type = string(v1.RuleTypeAlerting)
+	}
+
+	if args.Limit <= 0 {
+		args.Limit = defaultRulesLimit
+	}
+	if args.Offset < 0 {
+		args.Offset = 0
+	}
+
+	rules, err := tm.dependencies.HandlersManager.Rules(ctx, backendName, args.OrgID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to fetch rules from backend %q: %s", backendName, err.Error())), nil
+	}
+
+	matchesGlob := func(pattern, value string) bool {
+		if pattern == "" {
+			return true
+		}
+		matched, _ := filepath.Match(pattern, value)
+		return matched
+	}
+
+	entries := []map[string]interface{}{}
+	stateCount := map[string]int{}
+
+	for _, group := range rules.Groups {
+		if !matchesGlob(args.Group, group.Name) {
+			continue
+		}
+
+		for _, rule := range group.Rules {
+			var entry map[string]interface{}
+
+			switch r := rule.(type) {
+			case v1.AlertingRule:
+				if args.Type == string(v1.RuleTypeRecording) || !matchesGlob(args.Name, r.Name) {
+					continue
+				}
+				if args.State != "" && r.State != args.State {
+					continue
+				}
+				if args.Health != "" && string(r.Health) != args.Health {
+					continue
+				}
+
+				stateCount[r.State]++
+				entry = map[string]interface{}{
+					"type":            string(v1.RuleTypeAlerting),
+					"name":            r.Name,
+					"query":           r.Query,
+					"state":           r.State,
+					"for":             (time.Duration(r.Duration) * time.Second).String(),
+					"health":          string(r.Health),
+					"last_error":      r.LastError,
+					"last_evaluation": r.LastEvaluation.Format(time.RFC3339),
+					"evaluation_time": r.EvaluationTime,
+					"labels":          r.Labels,
+					"annotations":     r.Annotations,
+					"active_alerts":   len(r.Alerts),
+				}
+
+			case v1.RecordingRule:
+				if args.Type == string(v1.RuleTypeAlerting) || !matchesGlob(args.Name, r.Name) {
+					continue
+				}
+				if args.Health != "" && string(r.Health) != args.Health {
+					continue
+				}
+
+				entry = map[string]interface{}{
+					"type":            string(v1.RuleTypeRecording),
+					"name":            r.Name,
+					"query":           r.Query,
+					"health":          string(r.Health),
+					"last_error":      r.LastError,
+					"last_evaluation": r.LastEvaluation.Format(time.RFC3339),
+					"evaluation_time": r.EvaluationTime,
+					"labels":          r.Labels,
+				}
+
+			default:
+				continue
+			}
+
+			entry["group"] = group.Name
+			entry["file"] = group.File
+			entries = append(entries, entry)
+		}
+	}
+
+	totalRules := len(entries)
+	start, end := paginationBounds(totalRules, args.Offset, args.Limit)
+
+	encodedResult, err := encoder.Encode(map[string]interface{}{
+		"total_rules":       totalRules,
+		"alerting_by_state": stateCount,
+		"returned":          end - start,
+		"offset":            args.Offset,
+		"limit":             args.Limit,
+		"has_more":          end < totalRules,
+		"rules":             entries[start:end],
+	})
+	if err != nil {
+		return mcp.NewToolResultError("failed to marshal result: " + err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Rules [%s]:\n\n%s", backendName, encodedResult)), nil
+}
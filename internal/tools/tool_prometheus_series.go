@@ -0,0 +1,96 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const defaultSeriesLimit = 100
+
+func (tm *ToolsManager) HandleToolSeries(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		Backend  string   `json:"backend,omitempty"`
+		Match    []string `json:"match,omitempty"`
+		Start    string   `json:"start,omitempty"`
+		End      string   `json:"end,omitempty"`
+		Timezone string   `json:"timezone,omitempty"`
+		OrgID    string   `json:"org_id,omitempty"`
+		Limit    int      `json:"limit,omitempty"`
+		Offset   int      `json:"offset,omitempty"`
+	}
+
+	argsBytes, err := json.Marshal(request.Params.Arguments)
+	if err != nil {
+		return mcp.NewToolResultError("failed to marshal arguments: " + err.Error()), nil
+	}
+	if err = json.Unmarshal(argsBytes, &args); err != nil {
+		return mcp.NewToolResultError("failed to parse arguments: " + err.Error()), nil
+	}
+
+	encoder, err := tm.resultEncoder(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	backendName, err := tm.resolveBackend(args.Backend)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	tm.warnIfOrgIDIgnored(backendName, args.OrgID)
+
+	if len(args.Match) == 0 {
+		return mcp.NewToolResultError("match parameter is required"), nil
+	}
+
+	startTime, endTime, err := parseTimeWindow(args.Start, args.End, args.Timezone)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if args.Limit <= 0 {
+		args.Limit = defaultSeriesLimit
+	}
+	if args.Offset < 0 {
+		args.Offset = 0
+	}
+
+	// Only the series up to the requested page are fetched, one more tells whether more pages exist
+	series, err := tm.dependencies.HandlersManager.Series(ctx, backendName, args.Match, startTime, endTime, args.Offset+args.Limit+1, args.OrgID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to fetch series from backend %q: %s", backendName, err.Error())), nil
+	}
+
+	// Backends do not guarantee ordering, sort to keep pagination stable
+	sort.Slice(series, func(i, j int) bool {
+		return series[i].String() < series[j].String()
+	})
+
+	start, end := paginationBounds(len(series), args.Offset, args.Limit)
+	hasMore := end < len(series)
+
+	result := map[string]interface{}{
+		"returned": end - start,
+		"offset":   args.Offset,
+		"limit":    args.Limit,
+		"has_more": hasMore,
+		"series":   series[start:end],
+	}
+	// The total is only known when the backend returned everything
+	if !hasMore {
+		result["total_series"] = len(series)
+	}
+
+	encodedResult, err := encoder.Encode(result)
+	if err != nil {
+		return mcp.NewToolResultError("failed to marshal result: " + err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Series [%s]:\n\nMatch: %v\nStart: %s\nEnd: %s\n\n%s",
+		backendName, args.Match, startTime.Format(time.RFC3339), endTime.Format(time.RFC3339), encodedResult)), nil
+}
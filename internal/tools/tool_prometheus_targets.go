@@ -0,0 +1,177 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const defaultTargetsLimit = 50
+
+func (tm *ToolsManager) HandleToolTargets(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		Backend string `json:"backend,omitempty"`
+		State   string `json:"state,omitempty"`
+		Job     string `json:"job,omitempty"`
+		Health  string `json:"health,omitempty"`
+		Label   string `json:"label,omitempty"`
+		OrgID   string `json:"org_id,omitempty"`
+		Limit   int    `json:"limit,omitempty"`
+		Offset  int    `json:"offset,omitempty"`
+	}
+
+	argsBytes, err := json.Marshal(request.Params.Arguments)
+	if err != nil {
+		return mcp.NewToolResultError("failed to marshal arguments: " + err.Error()), nil
+	}
+	if err = json.Unmarshal(argsBytes, &args); err != nil {
+		return mcp.NewToolResultError("failed to parse arguments: " + err.Error()), nil
+	}
+
+	encoder, err := tm.resultEncoder(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	backendName, err := tm.resolveBackend(args.Backend)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	tm.warnIfOrgIDIgnored(backendName, args.OrgID)
+
+	if args.State == "" {
+		args.State = "active"
+	}
+	if args.State != "active" && args.State != "dropped" && args.State != "any" {
+		return mcp.NewToolResultError("invalid state, use one of: active, dropped, any"), nil
+	}
+
+	if args.Job != "" {
+		if _, err := filepath.Match(args.Job, ""); err != nil {
+			return mcp.NewToolResultError("invalid job glob pattern: " + err.Error()), nil
+		}
+	}
+
+	var labelName, labelGlob string
+	if args.Label != "" {
+		var found bool
+		labelName, labelGlob, found = strings.Cut(args.Label, "=")
+		if !found || labelName == "" {
+			return mcp.NewToolResultError("invalid label filter, use 'name=glob' (e.g., 'namespace=prod-*')"), nil
+		}
+		if _, err := filepath.Match(labelGlob, ""); err != nil {
+			return mcp.NewToolResultError("invalid label glob pattern: " + err.Error()), nil
+		}
+	}
+
+	if args.Limit <= 0 {
+		args.Limit = defaultTargetsLimit
+	}
+	if args.Offset < 0 {
+		args.Offset = 0
+	}
+
+	targets, err := tm.dependencies.HandlersManager.Targets(ctx, backendName, args.OrgID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to fetch targets from backend %q: %s", backendName, err.Error())), nil
+	}
+
+	matchesLabels := func(labels map[string]string) bool {
+		if args.Job != "" {
+			if matched, _ := filepath.Match(args.Job, labels["job"]); !matched {
+				return false
+			}
+		}
+		if labelName != "" {
+			if matched, _ := filepath.Match(labelGlob, labels[labelName]); !matched {
+				return false
+			}
+		}
+		return true
+	}
+
+	entries := []map[string]interface{}{}
+	healthCount := map[string]int{}
+
+	if args.State == "active" || args.State == "any" {
+		for _, target := range targets.Active {
+			labels := make(map[string]string, len(target.Labels))
+			for name, value := range target.Labels {
+				labels[string(name)] = string(value)
+			}
+
+			if !matchesLabels(labels) {
+				continue
+			}
+			if args.Health != "" && string(target.Health) != args.Health {
+				continue
+			}
+
+			healthCount[string(target.Health)]++
+			entries = append(entries, map[string]interface{}{
+				"state":                "active",
+				"scrape_pool":          target.ScrapePool,
+				"scrape_url":           target.ScrapeURL,
+				"health":               string(target.Health),
+				"last_error":           target.LastError,
+				"last_scrape":          target.LastScrape.Format(time.RFC3339),
+				"last_scrape_duration": target.LastScrapeDuration,
+				"labels":               labels,
+			})
+		}
+	}
+
+	// Dropped targets carry no health, so they are only listed when health is not filtered
+	if (args.State == "dropped" || args.State == "any") && args.Health == "" {
+		for _, target := range targets.Dropped {
+			if !matchesLabels(target.DiscoveredLabels) {
+				continue
+			}
+
+			entries = append(entries, map[string]interface{}{
+				"state":             "dropped",
+				"discovered_labels": target.DiscoveredLabels,
+			})
+		}
+	}
+
+	totalTargets := len(entries)
+	sort.SliceStable(entries, func(i, j int) bool {
+		return targetSortKey(entries[i]) < targetSortKey(entries[j])
+	})
+	start, end := paginationBounds(totalTargets, args.Offset, args.Limit)
+
+	encodedResult, err := encoder.Encode(map[string]interface{}{
+		"total_targets": totalTargets,
+		"health":        healthCount,
+		"returned":      end - start,
+		"offset":        args.Offset,
+		"limit":         args.Limit,
+		"has_more":      end < totalTargets,
+		"targets":       entries[start:end],
+	})
+	if err != nil {
+		return mcp.NewToolResultError("failed to marshal result: " + err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Scrape Targets [%s]:\n\n%s", backendName, encodedResult)), nil
+}
+
+// targetSortKey orders unhealthy targets first, then by scrape pool and URL
+func targetSortKey(entry map[string]interface{}) string {
+	rank := "2"
+	switch entry["health"] {
+	case "down":
+		rank = "0"
+	case "unknown":
+		rank = "1"
+	}
+	return fmt.Sprintf("%s|%v|%v", rank, entry["scrape_pool"], entry["scrape_url"])
+}
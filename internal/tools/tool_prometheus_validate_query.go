@@ -0,0 +1,89 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"prometheus-mcp/internal/promql"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func (tm *ToolsManager) HandleToolValidateQuery(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		Query string `json:"query"`
+	}
+
+	argsBytes, err := json.Marshal(request.Params.Arguments)
+	if err != nil {
+		return mcp.NewToolResultError("failed to marshal arguments: " + err.Error()), nil
+	}
+	if err = json.Unmarshal(argsBytes, &args); err != nil {
+		return mcp.NewToolResultError("failed to parse arguments: " + err.Error()), nil
+	}
+
+	encoder, err := tm.resultEncoder(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if args.Query == "" {
+		return mcp.NewToolResultError("query parameter is required"), nil
+	}
+
+	expr, err := promql.Parse(args.Query)
+	if err != nil {
+		var parseErr *promql.ParseError
+		if !errors.As(err, &parseErr) {
+			return mcp.NewToolResultError("failed to parse query: " + err.Error()), nil
+		}
+
+		encodedResult, err := encoder.Encode(map[string]interface{}{
+			"valid":  false,
+			"errors": parseErr.Positions,
+		})
+		if err != nil {
+			return mcp.NewToolResultError("failed to marshal result: " + err.Error()), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Query Validation:\n\nQuery: %s\n\n%s", args.Query, encodedResult)), nil
+	}
+
+	encodedResult, err := encoder.Encode(map[string]interface{}{
+		"valid":   true,
+		"summary": promql.Summarize(expr),
+	})
+	if err != nil {
+		return mcp.NewToolResultError("failed to marshal result: " + err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Query Validation:\n\nQuery: %s\n\n%s", args.Query, encodedResult)), nil
+}
+
+// preflightQuery parses the query locally when enabled in config, so syntax errors
+// are reported with their positions without a round trip to the backend
+func (tm *ToolsManager) preflightQuery(query string) *mcp.CallToolResult {
+	if !tm.dependencies.AppCtx.Config.Tools.ValidateQueries {
+		return nil
+	}
+
+	_, err := promql.Parse(query)
+	if err == nil {
+		return nil
+	}
+
+	var parseErr *promql.ParseError
+	if !errors.As(err, &parseErr) {
+		return mcp.NewToolResultError("invalid PromQL query: " + err.Error())
+	}
+
+	var sb strings.Builder
+	sb.WriteString("invalid PromQL query:")
+	for _, position := range parseErr.Positions {
+		sb.WriteString(fmt.Sprintf("\n\nline %d, column %d: %s\n%s", position.Line, position.Column, position.Message, position.Snippet))
+	}
+	return mcp.NewToolResultError(sb.String())
+}
@@ -5,6 +5,7 @@ import (
 	"sort"
 	"strings"
 
+	"prometheus-mcp/api"
 	"prometheus-mcp/internal/globals"
 	"prometheus-mcp/internal/handlers"
 	"prometheus-mcp/internal/middlewares"
@@ -32,36 +33,49 @@ func NewToolsManager(deps ToolsManagerDependencies) *ToolsManager {
 }
 
 func (tm *ToolsManager) resolveBackend(backendArg string) (string, error) {
-	backends := tm.dependencies.AppCtx.Config.Backends
+	return tm.resolveBackendOfType(backendArg, api.BackendTypePrometheus)
+}
+
+func (tm *ToolsManager) resolveAlertmanagerBackend(backendArg string) (string, error) {
+	return tm.resolveBackendOfType(backendArg, api.BackendTypeAlertmanager)
+}
+
+func (tm *ToolsManager) resolveBackendOfType(backendArg string, backendType string) (string, error) {
+	backends := tm.backendNames(backendType)
 	if len(backends) == 0 {
-		return "", fmt.Errorf("no backends configured")
+		return "", fmt.Errorf("no %s backends configured", backendType)
 	}
 	if backendArg == "" {
 		if len(backends) == 1 {
-			for name := range backends {
-				return name, nil
-			}
+			return backends[0], nil
 		}
 		return "", fmt.Errorf("backend parameter required when multiple backends are configured")
 	}
-	if _, ok := backends[backendArg]; !ok {
-		available := tm.backendNames()
-		return "", fmt.Errorf("unknown backend %q, available: [%s]", backendArg, strings.Join(available, ", "))
+
+	cfg, ok := tm.dependencies.AppCtx.Config.Backends[backendArg]
+	if !ok {
+		return "", fmt.Errorf("unknown backend %q, available: [%s]", backendArg, strings.Join(backends, ", "))
+	}
+	if cfg.Type != backendType {
+		return "", fmt.Errorf("backend %q is of type %q, this tool requires one of: [%s]", backendArg, cfg.Type, strings.Join(backends, ", "))
 	}
 	return backendArg, nil
 }
 
-func (tm *ToolsManager) backendNames() []string {
+func (tm *ToolsManager) backendNames(backendType string) []string {
 	names := make([]string, 0, len(tm.dependencies.AppCtx.Config.Backends))
-	for name := range tm.dependencies.AppCtx.Config.Backends {
+	for name, cfg := range tm.dependencies.AppCtx.Config.Backends {
+		if cfg.Type != backendType {
+			continue
+		}
 		names = append(names, name)
 	}
 	sort.Strings(names)
 	return names
 }
 
-func (tm *ToolsManager) buildBackendDescription() string {
-	names := tm.backendNames()
+func (tm *ToolsManager) buildBackendDescription(backendType string) string {
+	names := tm.backendNames(backendType)
 	desc := fmt.Sprintf("Backend to query. Available: [%s].", strings.Join(names, ", "))
 	if len(names) == 1 {
 		desc += fmt.Sprintf(" Defaults to '%s' if not specified.", names[0])
@@ -105,8 +119,29 @@ func (tm *ToolsManager) buildOrgIDDescription() string {
 	return baseDesc
 }
 
+// addTool registers a tool, adding the format argument of the structured results shared by all of them
+func (tm *ToolsManager) addTool(tool mcp.Tool, handler server.ToolHandlerFunc) {
+	defaultFormat := tm.dependencies.AppCtx.Config.Tools.OutputFormat
+	if defaultFormat == "" {
+		defaultFormat = api.OutputFormatTOON
+	}
+
+	mcp.WithString("format",
+		mcp.Description(fmt.Sprintf("Output format of the structured results. Defaults to '%s'", defaultFormat)),
+		mcp.Enum(outputFormats()...),
+	)(&tool)
+
+	tm.dependencies.McpServer.AddTool(tool, handler)
+}
+
+// timeFormatsDesc lists the formats accepted by the time arguments, see parseTime
+const timeFormatsDesc = "RFC3339, unix seconds, 'now', 'now-1h', 'now/d' or a duration ago like '2h'"
+
+// timezoneDesc describes the timezone argument of the tools taking times
+const timezoneDesc = "IANA timezone (e.g., 'Europe/Madrid') used for times without offset and for rounding like 'now/d'. Defaults to UTC"
+
 func (tm *ToolsManager) AddTools() {
-	backendDesc := tm.buildBackendDescription()
+	backendDesc := tm.buildBackendDescription(api.BackendTypePrometheus)
 	orgIDDesc := tm.buildOrgIDDescription()
 
 	tool := mcp.NewTool("prometheus_query",
@@ -114,44 +149,108 @@ func (tm *ToolsManager) AddTools() {
 		mcp.WithString("backend",
 			mcp.Description(backendDesc),
 		),
+		mcp.WithArray("backends",
+			mcp.WithStringItems(),
+			mcp.Description("Run the query concurrently against several backends and merge the results, tagging each series with a 'backend' label. Overrides 'backend'. Failing backends are reported without failing the call"),
+		),
 		mcp.WithString("query",
 			mcp.Required(),
 			mcp.Description("The PromQL query to execute"),
 		),
 		mcp.WithString("time",
-			mcp.Description("Timestamp for the query (RFC3339 format). If not provided, uses current time"),
+			mcp.Description(fmt.Sprintf("Timestamp for the query (%s). If not provided, uses current time", timeFormatsDesc)),
+		),
+		mcp.WithString("timezone",
+			mcp.Description(timezoneDesc),
 		),
 		mcp.WithString("org_id",
 			mcp.Description(orgIDDesc),
 		),
+		mcp.WithBoolean("lint",
+			mcp.Description("Append semantic lint warnings (e.g., rate over gauges, histogram_quantile without 'le') to the results. Defaults to false."),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of result series to return, in the order returned by the backend. Capped by the backend limits. Defaults to every series."),
+		),
+		mcp.WithNumber("offset",
+			mcp.Description("Number of result series to skip for pagination. Defaults to 0."),
+		),
+		mcp.WithNumber("max_samples",
+			mcp.Description("Maximum number of samples to return. The page ends before the first series exceeding it, which starts the next page. Capped by the backend limits. Defaults to 50000."),
+		),
 	)
-	tm.dependencies.McpServer.AddTool(tool, tm.HandleToolQuery)
+	tm.addTool(tool, tm.HandleToolQuery)
 
 	tool = mcp.NewTool("prometheus_range_query",
 		mcp.WithDescription("Execute a PromQL range query against a metrics backend"),
 		mcp.WithString("backend",
 			mcp.Description(backendDesc),
 		),
+		mcp.WithArray("backends",
+			mcp.WithStringItems(),
+			mcp.Description("Run the query concurrently against several backends and merge the results, tagging each series with a 'backend' label. Overrides 'backend'. Failing backends are reported without failing the call"),
+		),
 		mcp.WithString("query",
 			mcp.Required(),
 			mcp.Description("The PromQL query to execute"),
 		),
 		mcp.WithString("start",
 			mcp.Required(),
-			mcp.Description("Start time for the range query (RFC3339 format)"),
+			mcp.Description(fmt.Sprintf("Start time for the range query (%s)", timeFormatsDesc)),
 		),
 		mcp.WithString("end",
 			mcp.Required(),
-			mcp.Description("End time for the range query (RFC3339 format)"),
+			mcp.Description(fmt.Sprintf("End time for the range query (%s)", timeFormatsDesc)),
+		),
+		mcp.WithString("timezone",
+			mcp.Description(timezoneDesc),
 		),
 		mcp.WithString("step",
-			mcp.Description("Step duration for the range query (e.g., '30s', '1m', '5m'). Defaults to '1m'"),
+			mcp.Description("Step duration for the range query (e.g., '30s', '5m', '1d'). If not provided, computed from 'max_points'. Raised to stay under 11000 points per series"),
+		),
+		mcp.WithNumber("max_points",
+			mcp.Description("Points budget per series used to compute an aligned step when 'step' is not provided. Defaults to the server setting, or 500."),
 		),
 		mcp.WithString("org_id",
 			mcp.Description(orgIDDesc),
 		),
+		mcp.WithBoolean("summary",
+			mcp.Description("Return per-series statistics (min, max, mean, last, p50, p95, p99, stddev, count, first/last timestamps) instead of every sample. Defaults to false."),
+		),
+		mcp.WithNumber("top_n",
+			mcp.Description("In summary mode, keep the raw samples of the top N series ranked by 'sort_by', within 'max_samples'. Defaults to 0."),
+		),
+		mcp.WithString("sort_by",
+			mcp.Description("In summary mode, statistic used to rank series, biggest first. Defaults to 'max'."),
+			mcp.Enum("min", "max", "mean", "last", "p50", "p95", "p99", "stddev", "count"),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of result series to return, ordered by rank in summary mode and by label set otherwise. Capped by the backend limits. Defaults to every series."),
+		),
+		mcp.WithNumber("offset",
+			mcp.Description("Number of result series to skip for pagination. Defaults to 0."),
+		),
+		mcp.WithNumber("max_samples",
+			mcp.Description("Maximum number of samples to return. The page ends before the first series exceeding it, which starts the next page. Capped by the backend limits. Defaults to 50000."),
+		),
+		mcp.WithBoolean("sparkline",
+			mcp.Description("Return one unicode sparkline per series with its min, max and last values instead of every sample. Cannot be combined with 'summary'. Defaults to false."),
+		),
+		mcp.WithNumber("sparkline_width",
+			mcp.Description("Number of characters of each sparkline, capped by the number of steps. Defaults to 40, up to 200."),
+		),
+		mcp.WithString("chart",
+			mcp.Description("Also return the result as a line chart image in the given format, with title, legend and axis units"),
+			mcp.Enum("png", "svg"),
+		),
+		mcp.WithNumber("chart_series",
+			mcp.Description("Maximum number of series drawn on the chart, the rest are listed as omitted. Defaults to 10."),
+		),
+		mcp.WithString("chart_unit",
+			mcp.Description("Unit of the chart Y axis (e.g., 's', 'B', 'req/s'). Defaults to the unit suffix of the queried metric, like '_seconds' or '_bytes', with '/s' under rate, irate and deriv"),
+		),
 	)
-	tm.dependencies.McpServer.AddTool(tool, tm.HandleToolRangeQuery)
+	tm.addTool(tool, tm.HandleToolRangeQuery)
 
 	tool = mcp.NewTool("prometheus_list_metrics",
 		mcp.WithDescription("List all available metrics from a metrics backend"),
@@ -161,6 +260,156 @@ func (tm *ToolsManager) AddTools() {
 		mcp.WithString("query",
 			mcp.Description("Optional glob pattern to filter metrics (e.g., 'redis*', '*cpu*')"),
 		),
+		mcp.WithString("regex",
+			mcp.Description("Optional regular expression to filter metrics (e.g., '^node_(cpu|memory)_')"),
+		),
+		mcp.WithString("search",
+			mcp.Description("Optional case-insensitive substring the metric names must contain (e.g., 'latency')"),
+		),
+		mcp.WithArray("match",
+			mcp.WithStringItems(),
+			mcp.Description("Optional series selectors to scope the metrics, e.g. to a job or namespace (e.g., ['{job=\"api\"}', '{namespace=\"payments\"}'])"),
+		),
+		mcp.WithString("lookback",
+			mcp.Description("How far back to look for metrics with samples (e.g., '30m', '6h', '7d'). Defaults to '1h'."),
+		),
+		mcp.WithString("sort",
+			mcp.Description("Sort order of the metrics: by name, by name descending, or by number of series, biggest first. Series are counted from the TSDB head stats, or with a query over the lookback window when 'match' is set, whose cost grows with the matched series and the lookback. Defaults to 'name'."),
+			mcp.Enum("name", "name_desc", "series"),
+		),
+		mcp.WithString("org_id",
+			mcp.Description(orgIDDesc),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of metrics to return. Defaults to 100."),
+		),
+		mcp.WithNumber("offset",
+			mcp.Description("Number of metrics to skip for pagination. Defaults to 0."),
+		),
+		mcp.WithBoolean("include_metadata",
+			mcp.Description("Include type, help and unit next to each metric name. Defaults to false."),
+		),
+	)
+	tm.addTool(tool, tm.HandleToolListMetrics)
+
+	tool = mcp.NewTool("prometheus_series",
+		mcp.WithDescription("Find the series (label sets) matching one or more selectors in a time window, without evaluating a query"),
+		mcp.WithString("backend",
+			mcp.Description(backendDesc),
+		),
+		mcp.WithArray("match",
+			mcp.Required(),
+			mcp.WithStringItems(),
+			mcp.Description("Series selectors to match (e.g., ['up{job=\"api\"}', 'http_requests_total'])"),
+		),
+		mcp.WithString("start",
+			mcp.Description(fmt.Sprintf("Start of the time window (%s). Defaults to one hour before end", timeFormatsDesc)),
+		),
+		mcp.WithString("end",
+			mcp.Description(fmt.Sprintf("End of the time window (%s). Defaults to current time", timeFormatsDesc)),
+		),
+		mcp.WithString("timezone",
+			mcp.Description(timezoneDesc),
+		),
+		mcp.WithString("org_id",
+			mcp.Description(orgIDDesc),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of series to return. Defaults to 100."),
+		),
+		mcp.WithNumber("offset",
+			mcp.Description("Number of series to skip for pagination. Defaults to 0."),
+		),
+	)
+	tm.addTool(tool, tm.HandleToolSeries)
+
+	tool = mcp.NewTool("prometheus_label_names",
+		mcp.WithDescription("List the label names available in a metrics backend, optionally scoped to matching series"),
+		mcp.WithString("backend",
+			mcp.Description(backendDesc),
+		),
+		mcp.WithArray("match",
+			mcp.WithStringItems(),
+			mcp.Description("Optional series selectors to scope the label names (e.g., ['http_requests_total'])"),
+		),
+		mcp.WithString("start",
+			mcp.Description(fmt.Sprintf("Start of the time window (%s). Defaults to one hour before end", timeFormatsDesc)),
+		),
+		mcp.WithString("end",
+			mcp.Description(fmt.Sprintf("End of the time window (%s). Defaults to current time", timeFormatsDesc)),
+		),
+		mcp.WithString("timezone",
+			mcp.Description(timezoneDesc),
+		),
+		mcp.WithString("query",
+			mcp.Description("Optional glob pattern to filter label names (e.g., 'kube_*')"),
+		),
+		mcp.WithString("regex",
+			mcp.Description("Optional regular expression to filter label names"),
+		),
+		mcp.WithString("org_id",
+			mcp.Description(orgIDDesc),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of label names to return. Defaults to 100."),
+		),
+		mcp.WithNumber("offset",
+			mcp.Description("Number of label names to skip for pagination. Defaults to 0."),
+		),
+	)
+	tm.addTool(tool, tm.HandleToolLabelNames)
+
+	tool = mcp.NewTool("prometheus_label_values",
+		mcp.WithDescription("List the values a label takes in a metrics backend, optionally scoped to matching series"),
+		mcp.WithString("backend",
+			mcp.Description(backendDesc),
+		),
+		mcp.WithString("label",
+			mcp.Required(),
+			mcp.Description("Label name to list values for (e.g., 'namespace', 'job')"),
+		),
+		mcp.WithArray("match",
+			mcp.WithStringItems(),
+			mcp.Description("Optional series selectors to scope the label values (e.g., ['http_requests_total'])"),
+		),
+		mcp.WithString("start",
+			mcp.Description(fmt.Sprintf("Start of the time window (%s). Defaults to one hour before end", timeFormatsDesc)),
+		),
+		mcp.WithString("end",
+			mcp.Description(fmt.Sprintf("End of the time window (%s). Defaults to current time", timeFormatsDesc)),
+		),
+		mcp.WithString("timezone",
+			mcp.Description(timezoneDesc),
+		),
+		mcp.WithString("query",
+			mcp.Description("Optional glob pattern to filter label values (e.g., 'kube_*')"),
+		),
+		mcp.WithString("regex",
+			mcp.Description("Optional regular expression to filter label values"),
+		),
+		mcp.WithString("org_id",
+			mcp.Description(orgIDDesc),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of label values to return. Defaults to 100."),
+		),
+		mcp.WithNumber("offset",
+			mcp.Description("Number of label values to skip for pagination. Defaults to 0."),
+		),
+	)
+	tm.addTool(tool, tm.HandleToolLabelValues)
+
+	tool = mcp.NewTool("prometheus_metadata",
+		mcp.WithDescription("Get the type (counter, gauge, histogram...), help text and unit of metrics"),
+		mcp.WithString("backend",
+			mcp.Description(backendDesc),
+		),
+		mcp.WithString("metric",
+			mcp.Description("Exact metric name to get metadata for. If not provided, returns metadata for all metrics"),
+		),
+		mcp.WithString("query",
+			mcp.Description("Optional glob pattern to filter metrics (e.g., 'redis*', '*cpu*')"),
+		),
 		mcp.WithString("org_id",
 			mcp.Description(orgIDDesc),
 		),
@@ -171,5 +420,419 @@ func (tm *ToolsManager) AddTools() {
 			mcp.Description("Number of metrics to skip for pagination. Defaults to 0."),
 		),
 	)
-	tm.dependencies.McpServer.AddTool(tool, tm.HandleToolListMetrics)
+	tm.addTool(tool, tm.HandleToolMetadata)
+
+	tool = mcp.NewTool("prometheus_targets",
+		mcp.WithDescription("List scrape targets with their health, last error, last scrape time and scrape duration"),
+		mcp.WithString("backend",
+			mcp.Description(backendDesc),
+		),
+		mcp.WithString("state",
+			mcp.Description("Targets to list. Defaults to 'active'"),
+			mcp.Enum("active", "dropped", "any"),
+		),
+		mcp.WithString("job",
+			mcp.Description("Optional glob pattern to filter targets by job label (e.g., 'node*')"),
+		),
+		mcp.WithString("health",
+			mcp.Description("Optional health state to filter active targets"),
+			mcp.Enum("up", "down", "unknown"),
+		),
+		mcp.WithString("label",
+			mcp.Description("Optional label filter as 'name=glob' (e.g., 'namespace=prod-*')"),
+		),
+		mcp.WithString("org_id",
+			mcp.Description(orgIDDesc),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of targets to return. Defaults to 50."),
+		),
+		mcp.WithNumber("offset",
+			mcp.Description("Number of targets to skip for pagination. Defaults to 0."),
+		),
+	)
+	tm.addTool(tool, tm.HandleToolTargets)
+
+	tool = mcp.NewTool("prometheus_rules",
+		mcp.WithDescription("List alerting and recording rules with their expressions, state, health and last evaluation errors"),
+		mcp.WithString("backend",
+			mcp.Description(backendDesc),
+		),
+		mcp.WithString("type",
+			mcp.Description("Optional rule type to list"),
+			mcp.Enum("alerting", "recording"),
+		),
+		mcp.WithString("group",
+			mcp.Description("Optional glob pattern to filter rules by group name"),
+		),
+		mcp.WithString("name",
+			mcp.Description("Optional glob pattern to filter rules by rule name"),
+		),
+		mcp.WithString("state",
+			mcp.Description("Optional alerting rule state to filter by"),
+			mcp.Enum("firing", "pending", "inactive"),
+		),
+		mcp.WithString("health",
+			mcp.Description("Optional rule health to filter by"),
+			mcp.Enum("ok", "err", "unknown"),
+		),
+		mcp.WithString("org_id",
+			mcp.Description(orgIDDesc),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of rules to return. Defaults to 50."),
+		),
+		mcp.WithNumber("offset",
+			mcp.Description("Number of rules to skip for pagination. Defaults to 0."),
+		),
+	)
+	tm.addTool(tool, tm.HandleToolRules)
+
+	tool = mcp.NewTool("prometheus_alerts",
+		mcp.WithDescription("List the active (firing or pending) alerts evaluated by a metrics backend"),
+		mcp.WithString("backend",
+			mcp.Description(backendDesc),
+		),
+		mcp.WithString("name",
+			mcp.Description("Optional glob pattern to filter alerts by alertname"),
+		),
+		mcp.WithString("state",
+			mcp.Description("Optional alert state to filter by"),
+			mcp.Enum("firing", "pending"),
+		),
+		mcp.WithString("org_id",
+			mcp.Description(orgIDDesc),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of alerts to return. Defaults to 50."),
+		),
+		mcp.WithNumber("offset",
+			mcp.Description("Number of alerts to skip for pagination. Defaults to 0."),
+		),
+	)
+	tm.addTool(tool, tm.HandleToolAlerts)
+
+	tool = mcp.NewTool("prometheus_cardinality",
+		mcp.WithDescription("Analyze series cardinality: head series count, top metrics by series count, top label names by value count and by memory, and top label-value pairs by series count. The TSDB status API only reports memory per label name, not per label-value pair. Set 'metric' to drill into the per-label cardinality of a single metric"),
+		mcp.WithString("backend",
+			mcp.Description(backendDesc),
+		),
+		mcp.WithString("metric",
+			mcp.Description("Optional metric name to compute per-label cardinality for, through the series API. At most 10000 series are analyzed, 'truncated' tells when the metric has more"),
+		),
+		mcp.WithString("start",
+			mcp.Description(fmt.Sprintf("Start of the time window for the metric drill-down (%s). Defaults to one hour before end", timeFormatsDesc)),
+		),
+		mcp.WithString("end",
+			mcp.Description(fmt.Sprintf("End of the time window for the metric drill-down (%s). Defaults to current time", timeFormatsDesc)),
+		),
+		mcp.WithString("timezone",
+			mcp.Description(timezoneDesc),
+		),
+		mcp.WithString("org_id",
+			mcp.Description(orgIDDesc),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Number of entries in each top list. Defaults to 10."),
+		),
+	)
+	tm.addTool(tool, tm.HandleToolCardinality)
+
+	tool = mcp.NewTool("prometheus_exemplars",
+		mcp.WithDescription("Get the exemplars (sampled trace references) of a query in a time range, including links to traces when the backend declares a trace URL template"),
+		mcp.WithString("backend",
+			mcp.Description(backendDesc),
+		),
+		mcp.WithString("query",
+			mcp.Required(),
+			mcp.Description("The PromQL query to get exemplars for (e.g., 'http_request_duration_seconds_bucket{job=\"api\"}')"),
+		),
+		mcp.WithString("start",
+			mcp.Description(fmt.Sprintf("Start of the time range (%s). Defaults to one hour before end", timeFormatsDesc)),
+		),
+		mcp.WithString("end",
+			mcp.Description(fmt.Sprintf("End of the time range (%s). Defaults to current time", timeFormatsDesc)),
+		),
+		mcp.WithString("timezone",
+			mcp.Description(timezoneDesc),
+		),
+		mcp.WithString("org_id",
+			mcp.Description(orgIDDesc),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of exemplars to return. Defaults to 100."),
+		),
+	)
+	tm.addTool(tool, tm.HandleToolExemplars)
+
+	tool = mcp.NewTool("prometheus_validate_query",
+		mcp.WithDescription("Parse a PromQL query locally, without touching any backend. Returns precise syntax error positions, or a structured summary of its selectors, functions, aggregations and ranges"),
+		mcp.WithString("query",
+			mcp.Required(),
+			mcp.Description("The PromQL query to validate"),
+		),
+	)
+	tm.addTool(tool, tm.HandleToolValidateQuery)
+
+	tool = mcp.NewTool("prometheus_lint_query",
+		mcp.WithDescription("Check a PromQL query for semantic mistakes using backend metadata: rate/increase over gauges, raw counters without rate, histogram_quantile over non-bucket series or without 'le', and sum without by over high-cardinality metrics. Returns warnings with suggested rewrites"),
+		mcp.WithString("backend",
+			mcp.Description(backendDesc),
+		),
+		mcp.WithString("query",
+			mcp.Required(),
+			mcp.Description("The PromQL query to lint"),
+		),
+		mcp.WithString("org_id",
+			mcp.Description(orgIDDesc),
+		),
+	)
+	tm.addTool(tool, tm.HandleToolLintQuery)
+
+	tool = mcp.NewTool("prometheus_backend_info",
+		mcp.WithDescription("Get what a metrics backend is: flavor (Prometheus, Thanos, Mimir...), version and build info, storage retention, lookback delta, enabled feature flags and query-related flags. Use it to tailor queries to what the backend supports"),
+		mcp.WithString("backend",
+			mcp.Description(backendDesc),
+		),
+		mcp.WithString("org_id",
+			mcp.Description(orgIDDesc),
+		),
+		mcp.WithBoolean("all_flags",
+			mcp.Description("Return every command-line flag instead of only the query and storage related ones. Defaults to false."),
+		),
+	)
+	tm.addTool(tool, tm.HandleToolBackendInfo)
+
+	tool = mcp.NewTool("prometheus_list_backends",
+		mcp.WithDescription("List every configured backend (Prometheus-compatible and Alertmanager) with its type, URL without credentials, auth type, tenants, whether its client is initialized, and a live reachability and latency probe"),
+		mcp.WithBoolean("probe",
+			mcp.Description("Send a cheap request to each backend to check reachability and latency. Defaults to true."),
+		),
+	)
+	tm.addTool(tool, tm.HandleToolListBackends)
+
+	tool = mcp.NewTool("prometheus_compare",
+		mcp.WithDescription("Compare a range query over a window against the same window shifted back by one or more baselines (e.g., 1d, 7d ago). Series are aligned by label set and returned with their mean delta and percent change, plus per-baseline summary stats. Answers 'is this worse than last week?'"),
+		mcp.WithString("backend",
+			mcp.Description(backendDesc),
+		),
+		mcp.WithString("query",
+			mcp.Required(),
+			mcp.Description("The PromQL query to compare"),
+		),
+		mcp.WithString("start",
+			mcp.Description(fmt.Sprintf("Start time of the current window (%s). Defaults to one hour before end", timeFormatsDesc)),
+		),
+		mcp.WithString("end",
+			mcp.Description(fmt.Sprintf("End time of the current window (%s). Defaults to now", timeFormatsDesc)),
+		),
+		mcp.WithString("timezone",
+			mcp.Description(timezoneDesc),
+		),
+		mcp.WithString("step",
+			mcp.Description("Step duration for the range queries (e.g., '30s', '1m', '5m'). If not provided, computed from 'max_points'"),
+		),
+		mcp.WithNumber("max_points",
+			mcp.Description("Points budget per series used to compute an aligned step when 'step' is not provided. Defaults to the server setting, or 500."),
+		),
+		mcp.WithArray("baselines",
+			mcp.WithStringItems(),
+			mcp.Description("Offsets of the baseline windows (e.g., ['1d', '7d']). Defaults to ['1d', '7d']"),
+		),
+		mcp.WithString("org_id",
+			mcp.Description(orgIDDesc),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of comparison rows to return, biggest changes first. Defaults to 50."),
+		),
+	)
+	tm.addTool(tool, tm.HandleToolCompare)
+
+	tool = mcp.NewTool("prometheus_histogram_quantile",
+		mcp.WithDescription("Compute quantiles of a histogram without writing PromQL. Detects whether the metric is a classic (_bucket) or native histogram, builds the right histogram_quantile query and returns the quantile series per group, labeled with 'quantile'. Instant by default, range when start or end is provided"),
+		mcp.WithString("backend",
+			mcp.Description(backendDesc),
+		),
+		mcp.WithString("metric",
+			mcp.Required(),
+			mcp.Description("Histogram base metric name (e.g., 'http_request_duration_seconds'). A '_bucket' suffix is ignored"),
+		),
+		mcp.WithString("kind",
+			mcp.Description("Histogram kind. Detected from the series of the last hour when omitted, required when the histogram has no recent series"),
+			mcp.Enum("classic", "native"),
+		),
+		mcp.WithArray("matchers",
+			mcp.WithStringItems(),
+			mcp.Description("Optional label matchers to filter the series (e.g., ['job=\"api\"', 'code=~\"2..\"'])"),
+		),
+		mcp.WithArray("group_by",
+			mcp.WithStringItems(),
+			mcp.Description("Labels to compute separate quantiles for (e.g., ['route']). Defaults to a single overall group"),
+		),
+		mcp.WithArray("quantiles",
+			mcp.WithNumberItems(),
+			mcp.Description("Quantiles between 0 and 1. Defaults to [0.5, 0.9, 0.99]"),
+		),
+		mcp.WithString("rate_window",
+			mcp.Description("Range of the rate() applied to the histogram (e.g., '1m', '5m'). Defaults to '5m'"),
+		),
+		mcp.WithString("time",
+			mcp.Description(fmt.Sprintf("Evaluation time of the instant query (%s). Defaults to now", timeFormatsDesc)),
+		),
+		mcp.WithString("start",
+			mcp.Description("Start of the range (same formats as 'time'). Makes the query a range query, defaults to one hour before end"),
+		),
+		mcp.WithString("end",
+			mcp.Description("End of the range (same formats as 'time'). Makes the query a range query, defaults to now"),
+		),
+		mcp.WithString("step",
+			mcp.Description("Step of the range query. If not provided, computed from 'max_points'"),
+		),
+		mcp.WithNumber("max_points",
+			mcp.Description("Points budget per series used to compute the step of the range query. Defaults to the server setting, or 500."),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Range query only. Maximum number of result series to return, sorted by label set with the series of every quantile paged together. Capped by the backend limits. Defaults to every series."),
+		),
+		mcp.WithNumber("offset",
+			mcp.Description("Range query only. Number of result series to skip for pagination. Defaults to 0."),
+		),
+		mcp.WithNumber("max_samples",
+			mcp.Description("Range query only. Maximum number of samples to return. The page ends before the first series exceeding it, which starts the next page. Capped by the backend limits. Defaults to 50000."),
+		),
+		mcp.WithString("timezone",
+			mcp.Description(timezoneDesc),
+		),
+		mcp.WithString("org_id",
+			mcp.Description(orgIDDesc),
+		),
+	)
+	tm.addTool(tool, tm.HandleToolHistogramQuantile)
+
+	if len(tm.backendNames(api.BackendTypeAlertmanager)) > 0 {
+		tm.addAlertmanagerTools(orgIDDesc)
+	}
+}
+
+func (tm *ToolsManager) addAlertmanagerTools(orgIDDesc string) {
+	backendDesc := tm.buildBackendDescription(api.BackendTypeAlertmanager)
+
+	tool := mcp.NewTool("alertmanager_alerts",
+		mcp.WithDescription("List the alerts known by an Alertmanager, including whether they are silenced or inhibited"),
+		mcp.WithString("backend",
+			mcp.Description(backendDesc),
+		),
+		mcp.WithArray("matchers",
+			mcp.WithStringItems(),
+			mcp.Description("Optional label matchers to filter alerts (e.g., ['alertname=\"HighLatency\"', 'severity=~\"critical|warning\"'])"),
+		),
+		mcp.WithString("receiver",
+			mcp.Description("Optional regular expression to filter alerts by receiver"),
+		),
+		mcp.WithBoolean("active",
+			mcp.Description("Include active alerts. Defaults to true."),
+		),
+		mcp.WithBoolean("silenced",
+			mcp.Description("Include silenced alerts. Defaults to true."),
+		),
+		mcp.WithBoolean("inhibited",
+			mcp.Description("Include inhibited alerts. Defaults to true."),
+		),
+		mcp.WithString("org_id",
+			mcp.Description(orgIDDesc),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of alerts to return. Defaults to 50."),
+		),
+		mcp.WithNumber("offset",
+			mcp.Description("Number of alerts to skip for pagination. Defaults to 0."),
+		),
+	)
+	tm.addTool(tool, tm.HandleToolAlertmanagerAlerts)
+
+	tool = mcp.NewTool("alertmanager_silences",
+		mcp.WithDescription("List the silences configured in an Alertmanager"),
+		mcp.WithString("backend",
+			mcp.Description(backendDesc),
+		),
+		mcp.WithArray("matchers",
+			mcp.WithStringItems(),
+			mcp.Description("Optional label matchers to filter silences (e.g., ['alertname=\"HighLatency\"'])"),
+		),
+		mcp.WithString("state",
+			mcp.Description("Optional silence state to filter by"),
+			mcp.Enum("active", "pending", "expired"),
+		),
+		mcp.WithString("org_id",
+			mcp.Description(orgIDDesc),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of silences to return. Defaults to 50."),
+		),
+		mcp.WithNumber("offset",
+			mcp.Description("Number of silences to skip for pagination. Defaults to 0."),
+		),
+	)
+	tm.addTool(tool, tm.HandleToolAlertmanagerSilences)
+
+	// Write tools are only exposed when at least one backend opts in
+	writeEnabled := false
+	for _, cfg := range tm.dependencies.AppCtx.Config.Backends {
+		if cfg.Type == api.BackendTypeAlertmanager && cfg.Silences.WriteEnabled {
+			writeEnabled = true
+			break
+		}
+	}
+	if !writeEnabled {
+		return
+	}
+
+	tool = mcp.NewTool("alertmanager_create_silence",
+		mcp.WithDescription("Create a silence in an Alertmanager. Only available for backends with silence writes enabled, and limited to a maximum duration"),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithString("backend",
+			mcp.Description(backendDesc),
+		),
+		mcp.WithArray("matchers",
+			mcp.Required(),
+			mcp.WithStringItems(),
+			mcp.Description("Label matchers selecting the alerts to silence (e.g., ['alertname=\"HighLatency\"', 'namespace=\"prod\"'])"),
+		),
+		mcp.WithString("starts_at",
+			mcp.Description("Start of the silence as an absolute time (RFC3339 or unix seconds) or relative to now ('now', 'now+30m'). At most 7 days ahead. Defaults to current time"),
+		),
+		mcp.WithString("ends_at",
+			mcp.Description("End of the silence as an absolute time (RFC3339 or unix seconds) or relative to now ('now+2h'). For an end relative to the start use duration instead. Use either this or duration"),
+		),
+		mcp.WithString("timezone",
+			mcp.Description(timezoneDesc),
+		),
+		mcp.WithString("duration",
+			mcp.Description("Duration of the silence from its start (e.g., '30m', '2h', '1d'). Use either this or ends_at"),
+		),
+		mcp.WithString("comment",
+			mcp.Required(),
+			mcp.Description("Reason for the silence, ideally referencing the incident"),
+		),
+		mcp.WithString("org_id",
+			mcp.Description(orgIDDesc),
+		),
+	)
+	tm.addTool(tool, tm.HandleToolAlertmanagerCreateSilence)
+
+	tool = mcp.NewTool("alertmanager_expire_silence",
+		mcp.WithDescription("Expire an existing silence in an Alertmanager. Only available for backends with silence writes enabled"),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithString("backend",
+			mcp.Description(backendDesc),
+		),
+		mcp.WithString("silence_id",
+			mcp.Required(),
+			mcp.Description("ID of the silence to expire"),
+		),
+		mcp.WithString("org_id",
+			mcp.Description(orgIDDesc),
+		),
+	)
+	tm.addTool(tool, tm.HandleToolAlertmanagerExpireSilence)
 }
@@ -0,0 +1,184 @@
+package tools
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"prometheus-mcp/internal/chart"
+	"prometheus-mcp/internal/promql"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql/parser"
+)
+
+const (
+	chartFormatPNG = "png"
+	chartFormatSVG = "svg"
+)
+
+// chartMimeTypes maps the supported chart formats to the MIME type of the returned image
+var chartMimeTypes = map[string]string{
+	chartFormatPNG: "image/png",
+	chartFormatSVG: "image/svg+xml",
+}
+
+// metricUnitSuffixes maps Prometheus naming convention suffixes to the unit displayed on charts
+var metricUnitSuffixes = []struct {
+	suffix string
+	unit   string
+}{
+	{"_seconds", "s"},
+	{"_bytes", "B"},
+	{"_ratio", ""},
+	{"_percent", "%"},
+	{"_volts", "V"},
+	{"_amperes", "A"},
+	{"_joules", "J"},
+	{"_grams", "g"},
+	{"_meters", "m"},
+}
+
+// chartResult renders a range query matrix and returns it as image content following the text
+func chartResult(text string, matrix model.Matrix, format string, options chart.Options, loc *time.Location) (*mcp.CallToolResult, error) {
+	series := make([]chart.Series, 0, len(matrix))
+	for _, stream := range matrix {
+		s := chart.Series{Name: chartSeriesName(stream.Metric), Points: make([]chart.Point, 0, len(stream.Values))}
+		for _, sample := range stream.Values {
+			s.Points = append(s.Points, chart.Point{Time: sample.Timestamp.Time().In(loc), Value: float64(sample.Value)})
+		}
+		series = append(series, s)
+	}
+
+	var data []byte
+	var err error
+	switch format {
+	case chartFormatPNG:
+		data, err = chart.RenderPNG(series, options)
+	case chartFormatSVG:
+		data, err = chart.RenderSVG(series, options)
+	default:
+		return nil, fmt.Errorf("invalid chart format %q, use 'png' or 'svg'", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultImage(text, base64.StdEncoding.EncodeToString(data), chartMimeTypes[format]), nil
+}
+
+// chartSeriesName returns the label set of a series without the metric name, which is already in the title
+func chartSeriesName(metric model.Metric) string {
+	labels := metric.Clone()
+	name := string(labels[model.MetricNameLabel])
+	delete(labels, model.MetricNameLabel)
+
+	if len(labels) == 0 && name != "" {
+		return name
+	}
+	return labels.String()
+}
+
+// perSecondFunctions turn a selector into a per-second rate of change of its unit
+var perSecondFunctions = map[string]bool{"rate": true, "irate": true, "deriv": true}
+
+// inferChartUnit guesses the unit of a query from the base unit suffix of the first selector that carries one.
+// Counts (_count, and _bucket outside histogram_quantile) have no unit, and rates of change get a "/s" suffix
+func inferChartUnit(query string) string {
+	expr, err := promql.Parse(query)
+	if err != nil {
+		return ""
+	}
+
+	unit, found := "", false
+	parser.Inspect(expr, func(node parser.Node, path []parser.Node) error {
+		selector, ok := node.(*parser.VectorSelector)
+		if !ok || found {
+			return nil
+		}
+
+		name := selector.Name
+		for _, matcher := range selector.LabelMatchers {
+			if name == "" && matcher.Name == model.MetricNameLabel && matcher.Type == labels.MatchEqual {
+				name = matcher.Value
+			}
+		}
+
+		switch {
+		case strings.HasSuffix(name, "_count"):
+			return nil
+		case strings.HasSuffix(name, "_bucket"):
+			// Buckets are counts, but the quantiles computed from them are in the unit of the observations
+			if !pathCallsFunction(path, "histogram_quantile") {
+				return nil
+			}
+			unit, found = metricUnit(strings.TrimSuffix(name, "_bucket")), true
+			return nil
+		}
+
+		unit, found = metricUnit(strings.TrimSuffix(strings.TrimSuffix(name, "_total"), "_sum")), true
+		if unit != "" && perSecond(path, node) {
+			unit += "/s"
+		}
+		return nil
+	})
+	return unit
+}
+
+// metricUnit returns the unit of a metric name from its base unit suffix
+func metricUnit(name string) string {
+	for _, candidate := range metricUnitSuffixes {
+		if strings.HasSuffix(name, candidate.suffix) {
+			return candidate.unit
+		}
+	}
+	return ""
+}
+
+// pathCallsFunction reports whether any ancestor in the path is a call to the named function
+func pathCallsFunction(path []parser.Node, function string) bool {
+	for _, ancestor := range path {
+		if call, ok := ancestor.(*parser.Call); ok && call.Func.Name == function {
+			return true
+		}
+	}
+	return false
+}
+
+// perSecond reports whether a selector ends up as a per-second rate. A rate divided by another rate,
+// like rate(x_sum[5m]) / rate(x_count[5m]), is a plain ratio again
+func perSecond(path []parser.Node, node parser.Node) bool {
+	rated := false
+	for i, ancestor := range path {
+		switch ancestor := ancestor.(type) {
+		case *parser.Call:
+			if perSecondFunctions[ancestor.Func.Name] {
+				rated = true
+			}
+		case *parser.BinaryExpr:
+			child := node
+			if i+1 < len(path) {
+				child = path[i+1]
+			}
+			if ancestor.Op == parser.DIV && child == parser.Node(ancestor.LHS) && callsPerSecondFunction(ancestor.RHS) {
+				return false
+			}
+		}
+	}
+	return rated
+}
+
+// callsPerSecondFunction reports whether an expression contains a rate of change
+func callsPerSecondFunction(expr parser.Expr) bool {
+	found := false
+	parser.Inspect(expr, func(node parser.Node, _ []parser.Node) error {
+		if call, ok := node.(*parser.Call); ok && perSecondFunctions[call.Func.Name] {
+			found = true
+		}
+		return nil
+	})
+	return found
+}
@@ -0,0 +1,45 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/prometheus/common/model"
+)
+
+func TestInferChartUnit(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{`rate(http_request_duration_seconds_sum[5m]) / rate(http_request_duration_seconds_count[5m])`, "s"},
+		{`sum by (pod) (container_memory_working_set_bytes{namespace="default"})`, "B"},
+		{`rate(node_network_receive_bytes_total[5m])`, "B/s"},
+		{`irate({__name__="node_network_transmit_bytes_total"}[1m])`, "B/s"},
+		{`deriv(node_filesystem_free_bytes[1h])`, "B/s"},
+		{`rate(http_request_duration_seconds_count[5m])`, ""},
+		{`sum(http_request_duration_seconds_bucket{le="0.5"})`, ""},
+		{`histogram_quantile(0.99, sum by (le) (rate(http_request_duration_seconds_bucket[5m])))`, "s"},
+		{`sum by (pod) (rate(process_cpu_seconds_total[5m]))`, "s/s"},
+		{`up{job="api"}`, ""},
+		{`rate(`, ""},
+	}
+
+	for _, tt := range tests {
+		if got := inferChartUnit(tt.query); got != tt.want {
+			t.Errorf("inferChartUnit(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestChartSeriesName(t *testing.T) {
+	metric := model.Metric{model.MetricNameLabel: "up", "job": "api"}
+	if got := chartSeriesName(metric); got != `{job="api"}` {
+		t.Errorf("got %q, want {job=\"api\"}", got)
+	}
+	if got := chartSeriesName(model.Metric{model.MetricNameLabel: "up"}); got != "up" {
+		t.Errorf("got %q, want up", got)
+	}
+	if metric[model.MetricNameLabel] != "up" {
+		t.Error("the series metric was modified")
+	}
+}
@@ -0,0 +1,335 @@
+package tools
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"prometheus-mcp/api"
+
+	"github.com/alpkeskin/gotoon"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/prometheus/common/model"
+)
+
+// resultEncoder renders the structured part of tool results
+type resultEncoder interface {
+	Encode(value interface{}) (string, error)
+}
+
+var resultEncoders = map[string]resultEncoder{
+	api.OutputFormatTOON:     toonEncoder{},
+	api.OutputFormatJSON:     jsonEncoder{},
+	api.OutputFormatCSV:      csvEncoder{},
+	api.OutputFormatMarkdown: markdownEncoder{},
+}
+
+// outputFormats returns the names of the supported formats, sorted
+func outputFormats() []string {
+	formats := make([]string, 0, len(resultEncoders))
+	for format := range resultEncoders {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// resultEncoder returns the encoder for the format requested in the call, falling back to the server default and TOON
+func (tm *ToolsManager) resultEncoder(request mcp.CallToolRequest) (resultEncoder, error) {
+	format := request.GetString("format", "")
+	if format == "" {
+		format = tm.dependencies.AppCtx.Config.Tools.OutputFormat
+	}
+	if format == "" {
+		format = api.OutputFormatTOON
+	}
+
+	encoder, ok := resultEncoders[strings.ToLower(format)]
+	if !ok {
+		return nil, fmt.Errorf("unknown format %q, use one of: %s", format, strings.Join(outputFormats(), ", "))
+	}
+	return encoder, nil
+}
+
+type toonEncoder struct{}
+
+func (toonEncoder) Encode(value interface{}) (string, error) {
+	return gotoon.Encode(value)
+}
+
+type jsonEncoder struct{}
+
+func (jsonEncoder) Encode(value interface{}) (string, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+type csvEncoder struct{}
+
+// Encode renders one CSV table per tabular section, each one preceded by a "# name" line when there are several
+func (csvEncoder) Encode(value interface{}) (string, error) {
+	tables, err := toTables(value)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	for i, t := range tables {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+		if len(tables) > 1 {
+			buf.WriteString("# " + t.name + "\n")
+		}
+
+		writer := csv.NewWriter(&buf)
+		if err := writer.Write(t.columns); err != nil {
+			return "", err
+		}
+		if err := writer.WriteAll(t.rows); err != nil {
+			return "", err
+		}
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+type markdownEncoder struct{}
+
+// Encode renders one Markdown table per tabular section, each one preceded by its name in bold when there are several
+func (markdownEncoder) Encode(value interface{}) (string, error) {
+	tables, err := toTables(value)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	for i, t := range tables {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		if len(tables) > 1 {
+			sb.WriteString("**" + t.name + "**\n\n")
+		}
+
+		sb.WriteString(markdownRow(t.columns))
+		separators := make([]string, len(t.columns))
+		for j := range separators {
+			separators[j] = "---"
+		}
+		sb.WriteString(markdownRow(separators))
+		for _, row := range t.rows {
+			sb.WriteString(markdownRow(row))
+		}
+	}
+	return strings.TrimSuffix(sb.String(), "\n"), nil
+}
+
+func markdownRow(cells []string) string {
+	escaped := make([]string, len(cells))
+	for i, cell := range cells {
+		cell = strings.ReplaceAll(cell, "|", "\\|")
+		escaped[i] = strings.ReplaceAll(cell, "\n", "<br>")
+	}
+	return "| " + strings.Join(escaped, " | ") + " |\n"
+}
+
+// table represents a tabular section of a result
+type table struct {
+	name    string
+	columns []string
+	rows    [][]string
+}
+
+// toTables flattens a result into tables. Vectors and matrices become one row per sample with a column per label,
+// lists of objects become one row per object, and the scalar fields of objects are gathered in a "fields" table.
+// Nested values that do not fit in a cell are rendered as compact JSON
+func toTables(value interface{}) ([]table, error) {
+	switch v := value.(type) {
+	case model.Vector:
+		return []table{vectorTable("result", v)}, nil
+	case model.Matrix:
+		return []table{matrixTable("result", v)}, nil
+	}
+
+	// Normalize through JSON so structs, typed maps and slices are handled the same way
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var normalized interface{}
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return nil, err
+	}
+
+	tables := []table{}
+	fields := table{name: "fields", columns: []string{"field", "value"}}
+	collectTables("result", normalized, &fields, &tables)
+	if len(fields.rows) > 0 {
+		tables = append([]table{fields}, tables...)
+	}
+	if len(tables) == 0 {
+		tables = append(tables, table{name: "result", columns: []string{"value"}})
+	}
+	return tables, nil
+}
+
+// collectTables walks a normalized value, turning lists into tables and scalars into rows of the fields table
+func collectTables(name string, value interface{}, fields *table, tables *[]table) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			childName := key
+			if name != "result" {
+				childName = name + "." + key
+			}
+			collectTables(childName, v[key], fields, tables)
+		}
+
+	case []interface{}:
+		*tables = append(*tables, listTable(name, v))
+
+	default:
+		fields.rows = append(fields.rows, []string{name, cellValue(v)})
+	}
+}
+
+// listTable renders a list as a table, with a column per object key or a single value column for scalars
+func listTable(name string, list []interface{}) table {
+	columnSet := map[string]struct{}{}
+	for _, item := range list {
+		if object, ok := item.(map[string]interface{}); ok {
+			for key := range object {
+				columnSet[key] = struct{}{}
+			}
+		}
+	}
+
+	if len(columnSet) == 0 {
+		t := table{name: name, columns: []string{"value"}}
+		for _, item := range list {
+			t.rows = append(t.rows, []string{cellValue(item)})
+		}
+		return t
+	}
+
+	t := table{name: name, columns: sortedKeys(columnSet)}
+	for _, item := range list {
+		object, _ := item.(map[string]interface{})
+		row := make([]string, len(t.columns))
+		for i, column := range t.columns {
+			if cell, ok := object[column]; ok {
+				row[i] = cellValue(cell)
+			}
+		}
+		t.rows = append(t.rows, row)
+	}
+	return t
+}
+
+// vectorTable renders a vector with a column per label, followed by the timestamp and value
+func vectorTable(name string, vector model.Vector) table {
+	metrics := make([]model.Metric, 0, len(vector))
+	for _, sample := range vector {
+		metrics = append(metrics, sample.Metric)
+	}
+	labels := labelColumns(metrics)
+
+	t := table{name: name, columns: append(append([]string{}, labels...), "timestamp", "value")}
+	for _, sample := range vector {
+		row := labelCells(sample.Metric, labels)
+		value := sample.Value.String()
+		if sample.Histogram != nil {
+			value = sample.Histogram.String()
+		}
+		t.rows = append(t.rows, append(row, sample.Timestamp.String(), value))
+	}
+	return t
+}
+
+// matrixTable renders a matrix in long format: one row per sample, with a column per label
+func matrixTable(name string, matrix model.Matrix) table {
+	metrics := make([]model.Metric, 0, len(matrix))
+	for _, stream := range matrix {
+		metrics = append(metrics, stream.Metric)
+	}
+	labels := labelColumns(metrics)
+
+	t := table{name: name, columns: append(append([]string{}, labels...), "timestamp", "value")}
+	for _, stream := range matrix {
+		cells := labelCells(stream.Metric, labels)
+		for _, pair := range stream.Values {
+			t.rows = append(t.rows, append(append([]string{}, cells...), pair.Timestamp.String(), pair.Value.String()))
+		}
+		for _, pair := range stream.Histograms {
+			t.rows = append(t.rows, append(append([]string{}, cells...), pair.Timestamp.String(), pair.Histogram.String()))
+		}
+	}
+	return t
+}
+
+// labelColumns returns the union of the label names of the metrics, with the metric name first
+func labelColumns(metrics []model.Metric) []string {
+	set := map[string]struct{}{}
+	for _, metric := range metrics {
+		for name := range metric {
+			set[string(name)] = struct{}{}
+		}
+	}
+
+	_, hasName := set[model.MetricNameLabel]
+	delete(set, model.MetricNameLabel)
+	columns := sortedKeys(set)
+	if hasName {
+		columns = append([]string{model.MetricNameLabel}, columns...)
+	}
+	return columns
+}
+
+func labelCells(metric model.Metric, labels []string) []string {
+	cells := make([]string, len(labels))
+	for i, label := range labels {
+		cells[i] = string(metric[model.LabelName(label)])
+	}
+	return cells
+}
+
+// cellValue renders a normalized JSON value as a single cell
+func cellValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(data)
+}
+
+func sortedKeys(set map[string]struct{}) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
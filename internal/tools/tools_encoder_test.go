@@ -0,0 +1,65 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/prometheus/common/model"
+)
+
+func TestResultEncoders(t *testing.T) {
+	listing := map[string]interface{}{
+		"total": 2,
+		"metrics": []map[string]interface{}{
+			{"name": "up", "type": "gauge"},
+			{"name": "http_requests_total", "type": "counter"},
+		},
+	}
+	vector := model.Vector{
+		{Metric: model.Metric{"__name__": "up", "job": "api"}, Value: 1, Timestamp: 1705312800000},
+		{Metric: model.Metric{"__name__": "up", "instance": "db:9100"}, Value: 0, Timestamp: 1705312800000},
+	}
+
+	tests := []struct {
+		name    string
+		encoder resultEncoder
+		value   interface{}
+		want    string
+	}{
+		{
+			name:    "json",
+			encoder: jsonEncoder{},
+			value:   listing,
+			want:    `{"metrics":[{"name":"up","type":"gauge"},{"name":"http_requests_total","type":"counter"}],"total":2}`,
+		},
+		{
+			name:    "csv sections",
+			encoder: csvEncoder{},
+			value:   listing,
+			want:    "# fields\nfield,value\ntotal,2\n\n# metrics\nname,type\nup,gauge\nhttp_requests_total,counter",
+		},
+		{
+			name:    "csv vector",
+			encoder: csvEncoder{},
+			value:   vector,
+			want:    "__name__,instance,job,timestamp,value\nup,,api,1705312800,1\nup,db:9100,,1705312800,0",
+		},
+		{
+			name:    "markdown",
+			encoder: markdownEncoder{},
+			value:   []map[string]interface{}{{"name": "a|b", "value": 1.5}},
+			want:    "| name | value |\n| --- | --- |\n| a\\|b | 1.5 |",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.encoder.Encode(tt.value)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
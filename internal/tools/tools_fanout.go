@@ -0,0 +1,165 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/prometheus/common/model"
+)
+
+const (
+	// fanOutBackendLabel is the synthetic label added to every series of a fan-out query
+	fanOutBackendLabel model.LabelName = "backend"
+
+	// fanOutExportedPrefix renames the backend label series already carry, like Prometheus does for target labels
+	fanOutExportedPrefix = "exported_"
+)
+
+// backendQueryFunc executes a query against a single backend
+type backendQueryFunc func(ctx context.Context, backendName string) (interface{}, error)
+
+// resolveBackends validates a list of backend names, removing duplicates while keeping their order
+func (tm *ToolsManager) resolveBackends(backendArgs []string) ([]string, error) {
+	seen := map[string]struct{}{}
+	backends := make([]string, 0, len(backendArgs))
+	for _, backendArg := range backendArgs {
+		if backendArg == "" {
+			continue
+		}
+		backendName, err := tm.resolveBackend(backendArg)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := seen[backendName]; ok {
+			continue
+		}
+		seen[backendName] = struct{}{}
+		backends = append(backends, backendName)
+	}
+
+	if len(backends) == 0 {
+		return nil, fmt.Errorf("backends parameter must contain at least one backend")
+	}
+	return backends, nil
+}
+
+// fanOut runs the query against every backend concurrently and merges the results, tagging each series
+// with the backend it comes from. Failing backends are reported in the returned map instead of failing the call
+func (tm *ToolsManager) fanOut(ctx context.Context, backends []string, query backendQueryFunc) (model.Value, map[string]string) {
+	results := make([]interface{}, len(backends))
+	errs := make([]error, len(backends))
+
+	var wg sync.WaitGroup
+	for i, backendName := range backends {
+		wg.Add(1)
+		go func(i int, backendName string) {
+			defer wg.Done()
+			results[i], errs[i] = query(ctx, backendName)
+		}(i, backendName)
+	}
+	wg.Wait()
+
+	backendErrors := map[string]string{}
+	var vector model.Vector
+	var matrix model.Matrix
+	isMatrix := false
+
+	for i, backendName := range backends {
+		if errs[i] != nil {
+			backendErrors[backendName] = errs[i].Error()
+			continue
+		}
+
+		switch value := results[i].(type) {
+		case model.Vector:
+			for _, sample := range value {
+				tagged := *sample
+				tagged.Metric = tagBackend(sample.Metric, backendName)
+				vector = append(vector, &tagged)
+			}
+		case *model.Scalar:
+			vector = append(vector, &model.Sample{
+				Metric:    tagBackend(model.Metric{}, backendName),
+				Value:     value.Value,
+				Timestamp: value.Timestamp,
+			})
+		case model.Matrix:
+			isMatrix = true
+			for _, stream := range value {
+				tagged := *stream
+				tagged.Metric = tagBackend(stream.Metric, backendName)
+				matrix = append(matrix, &tagged)
+			}
+		default:
+			backendErrors[backendName] = fmt.Sprintf("unsupported result type %T for fan-out queries", results[i])
+		}
+	}
+
+	if isMatrix {
+		sort.Slice(matrix, func(i, j int) bool {
+			return matrix[i].Metric.String() < matrix[j].Metric.String()
+		})
+		return matrix, backendErrors
+	}
+
+	if vector == nil {
+		vector = model.Vector{}
+	}
+	sort.Slice(vector, func(i, j int) bool {
+		return vector[i].Metric.String() < vector[j].Metric.String()
+	})
+	return vector, backendErrors
+}
+
+// tagBackend returns a copy of the metric carrying the backend label. An existing backend label is moved
+// to exported_backend, prefixed again while the name is taken, as Prometheus does without honor_labels
+func tagBackend(metric model.Metric, backendName string) model.Metric {
+	tagged := metric.Clone()
+	if existing, ok := tagged[fanOutBackendLabel]; ok {
+		exported := fanOutExportedPrefix + fanOutBackendLabel
+		for _, taken := tagged[exported]; taken; _, taken = tagged[exported] {
+			exported = fanOutExportedPrefix + exported
+		}
+		tagged[exported] = existing
+	}
+	tagged[fanOutBackendLabel] = model.LabelValue(backendName)
+	return tagged
+}
+
+// fanOutFailure returns an error result when every backend failed, nil otherwise
+func fanOutFailure(backends []string, backendErrors map[string]string) *mcp.CallToolResult {
+	if len(backendErrors) < len(backends) {
+		return nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString("query failed on every backend:")
+	for _, backendName := range backends {
+		sb.WriteString(fmt.Sprintf("\n- %s: %s", backendName, backendErrors[backendName]))
+	}
+	return mcp.NewToolResultError(sb.String())
+}
+
+// formatBackendErrors renders the backends that failed during a fan-out query as a plain text section
+func formatBackendErrors(backendErrors map[string]string) string {
+	if len(backendErrors) == 0 {
+		return ""
+	}
+
+	names := make([]string, 0, len(backendErrors))
+	for name := range backendErrors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var sb strings.Builder
+	sb.WriteString("\n\nBackend Errors:")
+	for _, name := range names {
+		sb.WriteString(fmt.Sprintf("\n- %s: %s", name, backendErrors[name]))
+	}
+	return sb.String()
+}
@@ -0,0 +1,49 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/prometheus/common/model"
+)
+
+func TestTagBackend(t *testing.T) {
+	tests := []struct {
+		name   string
+		metric model.Metric
+		want   model.Metric
+	}{
+		{
+			name:   "adds backend label",
+			metric: model.Metric{"__name__": "up", "job": "api"},
+			want:   model.Metric{"__name__": "up", "job": "api", "backend": "eu"},
+		},
+		{
+			name:   "keeps existing backend label",
+			metric: model.Metric{"__name__": "up", "backend": "redis"},
+			want:   model.Metric{"__name__": "up", "backend": "eu", "exported_backend": "redis"},
+		},
+		{
+			name:   "keeps existing exported backend label",
+			metric: model.Metric{"__name__": "up", "backend": "redis", "exported_backend": "primary", "exported_exported_backend": "cache"},
+			want: model.Metric{
+				"__name__":                           "up",
+				"backend":                            "eu",
+				"exported_backend":                   "primary",
+				"exported_exported_backend":          "cache",
+				"exported_exported_exported_backend": "redis",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tagBackend(tt.metric, "eu")
+			if !got.Equal(tt.want) {
+				t.Errorf("tagBackend() = %v, want %v", got, tt.want)
+			}
+			if _, ok := tt.metric["backend"]; ok && tt.metric["backend"] == "eu" {
+				t.Errorf("tagBackend() modified the original metric")
+			}
+		})
+	}
+}
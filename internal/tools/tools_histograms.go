@@ -0,0 +1,248 @@
+package tools
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/prometheus/common/model"
+)
+
+// renderedQuantiles are the quantiles derived from native histogram buckets
+var renderedQuantiles = []struct {
+	name string
+	q    float64
+}{
+	{"p50", 0.5},
+	{"p90", 0.9},
+	{"p99", 0.99},
+}
+
+// renderHistograms replaces the native histograms of a query result by readable structures: count, sum,
+// derived quantiles and buckets. Results without native histograms are returned unchanged
+func renderHistograms(result interface{}) interface{} {
+	switch value := result.(type) {
+	case model.Vector:
+		if !vectorHasHistograms(value) {
+			return value
+		}
+		samples := make([]map[string]interface{}, 0, len(value))
+		for _, sample := range value {
+			entry := map[string]interface{}{
+				"metric":    sample.Metric,
+				"timestamp": sample.Timestamp,
+			}
+			if sample.Histogram != nil {
+				entry["histogram"] = renderHistogram(sample.Histogram, true)
+			} else {
+				entry["value"] = sample.Value
+			}
+			samples = append(samples, entry)
+		}
+		return samples
+
+	case model.Matrix:
+		if !matrixHasHistograms(value) {
+			return value
+		}
+		streams := make([]map[string]interface{}, 0, len(value))
+		for _, stream := range value {
+			entry := map[string]interface{}{
+				"metric": stream.Metric,
+			}
+			if len(stream.Values) > 0 {
+				entry["values"] = stream.Values
+			}
+			if len(stream.Histograms) > 0 {
+				points := make([]map[string]interface{}, 0, len(stream.Histograms))
+				for _, pair := range stream.Histograms {
+					point := renderHistogram(pair.Histogram, false)
+					point["timestamp"] = pair.Timestamp
+					points = append(points, point)
+				}
+				entry["histograms"] = points
+
+				// Buckets of every point would flood the output, only the latest ones are kept
+				last := stream.Histograms[len(stream.Histograms)-1]
+				entry["last_buckets"] = renderBuckets(last.Histogram.Buckets)
+			}
+			streams = append(streams, entry)
+		}
+		return streams
+	}
+
+	return result
+}
+
+func vectorHasHistograms(vector model.Vector) bool {
+	for _, sample := range vector {
+		if sample.Histogram != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func matrixHasHistograms(matrix model.Matrix) bool {
+	for _, stream := range matrix {
+		if len(stream.Histograms) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// renderHistogram renders the count, sum and derived quantiles of a native histogram, and optionally its buckets
+func renderHistogram(histogram *model.SampleHistogram, withBuckets bool) map[string]interface{} {
+	rendered := map[string]interface{}{
+		"count": float64(histogram.Count),
+		"sum":   roundFloat(float64(histogram.Sum), summaryDigits),
+	}
+	for _, quantile := range renderedQuantiles {
+		rendered[quantile.name] = roundFloat(histogramQuantile(quantile.q, histogram), summaryDigits)
+	}
+	if withBuckets {
+		rendered["buckets"] = renderBuckets(histogram.Buckets)
+	}
+	return rendered
+}
+
+// renderBuckets renders buckets as rows with their interval in mathematical notation, e.g. "(0.5,1]"
+func renderBuckets(buckets model.HistogramBuckets) []map[string]interface{} {
+	rows := make([]map[string]interface{}, 0, len(buckets))
+	for _, bucket := range buckets {
+		lowerBracket, upperBracket := "(", ")"
+		if bucket.Boundaries == 1 || bucket.Boundaries == 3 {
+			lowerBracket = "["
+		}
+		if bucket.Boundaries == 0 || bucket.Boundaries == 3 {
+			upperBracket = "]"
+		}
+
+		rows = append(rows, map[string]interface{}{
+			"range": fmt.Sprintf("%s%g,%g%s", lowerBracket, float64(bucket.Lower), float64(bucket.Upper), upperBracket),
+			"count": float64(bucket.Count),
+		})
+	}
+	return rows
+}
+
+// histogramQuantile estimates a quantile from native histogram buckets the way Prometheus histogram_quantile()
+// does: buckets are walked from the closest end, values are interpolated exponentially within standard
+// exponential buckets and linearly within custom buckets and the zero bucket. Returns NaN for empty histograms
+func histogramQuantile(q float64, histogram *model.SampleHistogram) float64 {
+	switch {
+	case q < 0:
+		return math.Inf(-1)
+	case q > 1:
+		return math.Inf(1)
+	case histogram == nil || histogram.Count <= 0 || len(histogram.Buckets) == 0 || math.IsNaN(q):
+		return math.NaN()
+	}
+
+	buckets := make(model.HistogramBuckets, len(histogram.Buckets))
+	copy(buckets, histogram.Buckets)
+	sort.SliceStable(buckets, func(i, j int) bool {
+		return buckets[i].Lower < buckets[j].Lower
+	})
+	customBuckets := usesCustomBuckets(buckets)
+
+	total := float64(histogram.Count)
+	sum := float64(histogram.Sum)
+
+	// NaN observations are only counted in the total, so they force walking the buckets forward
+	forward := math.IsNaN(sum) || q < 0.5
+	rank := (1 - q) * total
+	if forward {
+		rank = q * total
+	}
+
+	var lower, upper, bucketCount, cumulative float64
+	for i := range buckets {
+		bucket := buckets[i]
+		if !forward {
+			bucket = buckets[len(buckets)-1-i]
+		}
+		if bucket.Count <= 0 {
+			continue
+		}
+		lower, upper, bucketCount = float64(bucket.Lower), float64(bucket.Upper), float64(bucket.Count)
+		cumulative += bucketCount
+		if cumulative >= rank {
+			break
+		}
+	}
+
+	hasNegative, hasPositive := false, false
+	for _, bucket := range buckets {
+		hasNegative = hasNegative || bucket.Upper <= 0
+		hasPositive = hasPositive || bucket.Lower >= 0
+	}
+
+	switch {
+	case !customBuckets && lower < 0 && upper > 0:
+		// The zero bucket is assumed to only hold values of the sign of the other buckets
+		if !hasNegative && hasPositive {
+			lower = 0
+		} else if !hasPositive && hasNegative {
+			upper = 0
+		}
+	case customBuckets && math.IsInf(lower, -1):
+		if upper <= 0 {
+			return upper
+		}
+		lower = 0
+	case customBuckets && math.IsInf(upper, 1):
+		return lower
+	}
+
+	// NaN observations can leave the rank above the last bucket, which then gives its upper bound
+	cumulative = math.Min(cumulative, total)
+	if cumulative < rank {
+		return upper
+	}
+
+	if forward {
+		rank -= cumulative - bucketCount
+	} else {
+		rank = cumulative - rank
+	}
+	fraction := rank / bucketCount
+
+	if customBuckets || (lower <= 0 && upper >= 0) {
+		return lower + (upper-lower)*fraction
+	}
+
+	// Exponential bucket boundaries are evenly spaced on a logarithmic scale
+	logLower := math.Log2(math.Abs(lower))
+	logUpper := math.Log2(math.Abs(upper))
+	if lower > 0 {
+		return math.Exp2(logLower + (logUpper-logLower)*fraction)
+	}
+	return -math.Exp2(logUpper + (logLower-logUpper)*(1-fraction))
+}
+
+// usesCustomBuckets tells whether a histogram has custom bucket boundaries, as the query API does not say so.
+// Custom buckets have infinite bounds or, unlike exponential ones, different ratios between their bounds
+func usesCustomBuckets(buckets model.HistogramBuckets) bool {
+	ratio := 0.0
+	for _, bucket := range buckets {
+		lower, upper := float64(bucket.Lower), float64(bucket.Upper)
+		if math.IsInf(lower, 0) || math.IsInf(upper, 0) {
+			return true
+		}
+		if lower <= 0 && upper >= 0 {
+			continue
+		}
+
+		bucketRatio := upper / lower
+		if lower < 0 {
+			bucketRatio = lower / upper
+		}
+		if ratio != 0 && math.Abs(bucketRatio-ratio) > 1e-9*ratio {
+			return true
+		}
+		ratio = bucketRatio
+	}
+	return false
+}
@@ -0,0 +1,104 @@
+package tools
+
+import (
+	"math"
+	"testing"
+
+	"github.com/prometheus/common/model"
+)
+
+func TestHistogramQuantile(t *testing.T) {
+	// Schema 0 exponential buckets
+	exponential := &model.SampleHistogram{
+		Count: 100,
+		Sum:   150,
+		Buckets: model.HistogramBuckets{
+			{Boundaries: 0, Lower: 1, Upper: 2, Count: 50},
+			{Boundaries: 0, Lower: 0.5, Upper: 1, Count: 40},
+			{Boundaries: 0, Lower: 2, Upper: 4, Count: 10},
+		},
+	}
+
+	// Custom buckets, as converted from classic histograms
+	custom := &model.SampleHistogram{
+		Count: 100,
+		Sum:   100,
+		Buckets: model.HistogramBuckets{
+			{Boundaries: 0, Lower: model.FloatString(math.Inf(-1)), Upper: 1, Count: 50},
+			{Boundaries: 0, Lower: 1, Upper: 2, Count: 50},
+		},
+	}
+
+	// NaN observations are counted in the total but in no bucket
+	withNaN := &model.SampleHistogram{
+		Count: 100,
+		Sum:   model.FloatString(math.NaN()),
+		Buckets: model.HistogramBuckets{
+			{Boundaries: 0, Lower: 1, Upper: 2, Count: 50},
+			{Boundaries: 0, Lower: 2, Upper: 4, Count: 40},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		histogram *model.SampleHistogram
+		q         float64
+		want      float64
+	}{
+		{name: "exponential, walking forward", histogram: exponential, q: 0.2, want: math.Sqrt2 / 2},
+		{name: "exponential, walking backwards", histogram: exponential, q: 0.5, want: math.Exp2(0.2)},
+		{name: "exponential, bucket boundary", histogram: exponential, q: 0.9, want: 2},
+		{name: "exponential, middle of the last bucket", histogram: exponential, q: 0.95, want: 2 * math.Sqrt2},
+		{name: "exponential, maximum", histogram: exponential, q: 1, want: 4},
+		{name: "custom, first bucket starts at zero", histogram: custom, q: 0.25, want: 0.5},
+		{name: "custom, linear", histogram: custom, q: 0.75, want: 1.5},
+		{name: "rank above the last bucket", histogram: withNaN, q: 0.95, want: 4},
+	}
+
+	for _, tt := range tests {
+		got := histogramQuantile(tt.q, tt.histogram)
+		if math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s: histogramQuantile(%v) = %v, want %v", tt.name, tt.q, got, tt.want)
+		}
+	}
+
+	if got := histogramQuantile(0.5, &model.SampleHistogram{}); !math.IsNaN(got) {
+		t.Errorf("histogramQuantile() of an empty histogram = %v, want NaN", got)
+	}
+}
+
+func TestRenderHistogramsKeepsFloatResults(t *testing.T) {
+	vector := model.Vector{{Metric: model.Metric{"job": "api"}, Value: 1}}
+	if _, ok := renderHistograms(vector).(model.Vector); !ok {
+		t.Errorf("float vectors should be returned unchanged")
+	}
+
+	histogramVector := model.Vector{{
+		Metric:    model.Metric{"job": "api"},
+		Histogram: &model.SampleHistogram{Count: 1, Sum: 1, Buckets: model.HistogramBuckets{{Lower: 0, Upper: 1, Count: 1}}},
+	}}
+	rendered, ok := renderHistograms(histogramVector).([]map[string]interface{})
+	if !ok || len(rendered) != 1 {
+		t.Fatalf("histogram vectors should be rendered, got %T", renderHistograms(histogramVector))
+	}
+	histogram := rendered[0]["histogram"].(map[string]interface{})
+	if histogram["p50"] != 0.5 {
+		t.Errorf("p50 = %v, want 0.5", histogram["p50"])
+	}
+
+	// NaN sums must not break encoders refusing NaN, like JSON
+	nanSum := renderHistogram(&model.SampleHistogram{Count: 1, Sum: model.FloatString(math.NaN())}, false)
+	if nanSum["sum"] != nil {
+		t.Errorf("sum = %v, want nil", nanSum["sum"])
+	}
+
+	// Sub-millisecond latencies in seconds keep their precision
+	fast := renderHistogram(&model.SampleHistogram{
+		Count:   10,
+		Sum:     0.00025,
+		Buckets: model.HistogramBuckets{{Boundaries: 0, Lower: model.FloatString(math.Inf(-1)), Upper: 0.00004, Count: 10}},
+	}, false)
+	if fast["sum"] != 0.00025 || fast["p50"] != 0.00002 {
+		t.Errorf("sum = %v and p50 = %v, want 0.00025 and 0.00002", fast["sum"], fast["p50"])
+	}
+}
@@ -0,0 +1,194 @@
+package tools
+
+import (
+	"sort"
+
+	"github.com/prometheus/common/model"
+)
+
+const (
+	// defaultResultMaxSamples is the number of samples returned per call when not limited by config
+	defaultResultMaxSamples = 50000
+)
+
+// resultLimits represents the size limits applied to a query result
+type resultLimits struct {
+	Limit      int
+	Offset     int
+	MaxSamples int
+}
+
+// limitsFor computes the limits of a call. Per-call values can only lower the limits configured
+// for the backends, the strictest backend wins when querying several of them
+func (tm *ToolsManager) limitsFor(backendNames []string, limitArg, offsetArg, maxSamplesArg int) resultLimits {
+	maxSeries, maxSamples := 0, 0
+	for _, name := range backendNames {
+		limits := tm.dependencies.AppCtx.Config.Backends[name].Limits
+		maxSeries = minPositive(maxSeries, limits.MaxSeries)
+		maxSamples = minPositive(maxSamples, limits.MaxSamples)
+	}
+	if maxSamples == 0 {
+		maxSamples = defaultResultMaxSamples
+	}
+
+	if limitArg > 0 && (maxSeries == 0 || limitArg < maxSeries) {
+		maxSeries = limitArg
+	}
+	if maxSamplesArg > 0 && maxSamplesArg < maxSamples {
+		maxSamples = maxSamplesArg
+	}
+	if offsetArg < 0 {
+		offsetArg = 0
+	}
+
+	return resultLimits{
+		Limit:      maxSeries,
+		Offset:     offsetArg,
+		MaxSamples: maxSamples,
+	}
+}
+
+// applyResultLimits keeps the requested page of a vector or matrix and applies the samples budget.
+// Vectors keep the order returned by the backend, so sort() and topk() results stay ranked, while matrices
+// are sorted by label set. Summary mode ranks its series in summarizeMatrix instead, and only shares the
+// page bounds. The returned map describes what was returned and dropped.
+// A zero Limit returns every series and a zero MaxSamples disables the samples budget
+func applyResultLimits(result interface{}, limits resultLimits) (interface{}, map[string]interface{}) {
+	switch value := result.(type) {
+	case model.Vector:
+		start, end := resultPageBounds(len(value), limits)
+		page := value[start:end]
+
+		droppedSeries := 0
+		if limits.MaxSamples > 0 && len(page) > limits.MaxSamples {
+			droppedSeries = len(page) - limits.MaxSamples
+			page = page[:limits.MaxSamples]
+		}
+
+		return page, paginationInfo(len(value), limits, len(page), droppedSeries, droppedSeries)
+
+	case model.Matrix:
+		sorted := make(model.Matrix, len(value))
+		copy(sorted, value)
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return sorted[i].Metric.String() < sorted[j].Metric.String()
+		})
+
+		start, end := resultPageBounds(len(sorted), limits)
+		kept, droppedSeries, droppedSamples := applySamplesBudget(sorted[start:end], limits.MaxSamples)
+
+		return kept, paginationInfo(len(sorted), limits, len(kept), droppedSeries, droppedSamples)
+	}
+
+	return result, nil
+}
+
+// resultPageBounds returns the bounds of the requested page, up to the end of the result when unlimited
+func resultPageBounds(total int, limits resultLimits) (int, int) {
+	if limits.Limit <= 0 {
+		return paginationBounds(total, limits.Offset, total)
+	}
+	return paginationBounds(total, limits.Offset, limits.Limit)
+}
+
+// applySamplesBudget keeps the leading series of a matrix that fit in the samples budget. The series from
+// the first one not fitting are dropped, so the next page starts with them. A zero budget keeps everything
+func applySamplesBudget(matrix model.Matrix, maxSamples int) (model.Matrix, int, int) {
+	if maxSamples <= 0 {
+		return matrix, 0, 0
+	}
+
+	kept := model.Matrix{}
+	samples, droppedSamples := 0, 0
+	for i, stream := range matrix {
+		streamSamples := len(stream.Values) + len(stream.Histograms)
+		if samples+streamSamples <= maxSamples {
+			kept = append(kept, stream)
+			samples += streamSamples
+			continue
+		}
+
+		// A single series bigger than the whole budget keeps its latest samples, so something is returned
+		if len(kept) == 0 {
+			truncated := *stream
+			truncated.Values = lastSamples(stream.Values, maxSamples)
+			truncated.Histograms = lastHistograms(stream.Histograms, maxSamples-len(truncated.Values))
+			kept = append(kept, &truncated)
+			droppedSamples += streamSamples - len(truncated.Values) - len(truncated.Histograms)
+			continue
+		}
+
+		for _, dropped := range matrix[i:] {
+			droppedSamples += len(dropped.Values) + len(dropped.Histograms)
+		}
+		return kept, len(matrix) - i, droppedSamples
+	}
+
+	return kept, 0, droppedSamples
+}
+
+// paginationInfo describes the page of series returned and what the samples budget dropped.
+// The next page starts after the returned series, so series dropped by the budget are not skipped
+func paginationInfo(total int, limits resultLimits, returned, droppedSeries, droppedSamples int) map[string]interface{} {
+	end := limits.Offset + returned
+
+	info := map[string]interface{}{
+		"total_series":    total,
+		"offset":          limits.Offset,
+		"limit":           limits.Limit,
+		"max_samples":     limits.MaxSamples,
+		"returned_series": returned,
+		"dropped_series":  droppedSeries,
+		"dropped_samples": droppedSamples,
+		"truncated":       droppedSeries > 0 || droppedSamples > 0,
+		"has_more":        end < total,
+	}
+	if end < total {
+		info["next_offset"] = end
+	}
+	return info
+}
+
+// formatPagination renders the pagination details of a result as a section appended to the results
+func formatPagination(encoder resultEncoder, pagination map[string]interface{}) (string, error) {
+	if pagination == nil {
+		return "", nil
+	}
+
+	encodedPagination, err := encoder.Encode(pagination)
+	if err != nil {
+		return "", err
+	}
+	return "\n\nPagination:\n" + encodedPagination, nil
+}
+
+// minPositive returns the smallest positive value, zero when none is
+func minPositive(a, b int) int {
+	if a <= 0 {
+		return max(b, 0)
+	}
+	if b <= 0 || a < b {
+		return a
+	}
+	return b
+}
+
+func lastSamples(values []model.SamplePair, n int) []model.SamplePair {
+	if n <= 0 {
+		return nil
+	}
+	if len(values) > n {
+		return values[len(values)-n:]
+	}
+	return values
+}
+
+func lastHistograms(values []model.SampleHistogramPair, n int) []model.SampleHistogramPair {
+	if n <= 0 {
+		return nil
+	}
+	if len(values) > n {
+		return values[len(values)-n:]
+	}
+	return values
+}
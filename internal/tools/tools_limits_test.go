@@ -0,0 +1,145 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/prometheus/common/model"
+)
+
+func stream(job string, samples int) *model.SampleStream {
+	values := make([]model.SamplePair, samples)
+	for i := range values {
+		values[i] = model.SamplePair{Timestamp: model.Time(i * 1000), Value: model.SampleValue(i)}
+	}
+	return &model.SampleStream{Metric: model.Metric{"job": model.LabelValue(job)}, Values: values}
+}
+
+func TestApplyResultLimits(t *testing.T) {
+	matrix := model.Matrix{stream("c", 10), stream("a", 10), stream("d", 10), stream("b", 10)}
+
+	tests := []struct {
+		name               string
+		limits             resultLimits
+		wantJobs           []string
+		wantSamples        int
+		wantDroppedSeries  int
+		wantDroppedSamples int
+		wantHasMore        bool
+	}{
+		{
+			name:        "first page sorted by labels",
+			limits:      resultLimits{Limit: 2, MaxSamples: 100},
+			wantJobs:    []string{"a", "b"},
+			wantSamples: 10,
+			wantHasMore: true,
+		},
+		{
+			name:        "second page",
+			limits:      resultLimits{Limit: 2, Offset: 2, MaxSamples: 100},
+			wantJobs:    []string{"c", "d"},
+			wantSamples: 10,
+		},
+		{
+			name:               "samples budget drops whole series",
+			limits:             resultLimits{Limit: 10, MaxSamples: 25},
+			wantJobs:           []string{"a", "b"},
+			wantSamples:        10,
+			wantDroppedSeries:  2,
+			wantDroppedSamples: 20,
+			wantHasMore:        true,
+		},
+		{
+			name:        "next page starts with the series dropped by the samples budget",
+			limits:      resultLimits{Limit: 10, Offset: 2, MaxSamples: 25},
+			wantJobs:    []string{"c", "d"},
+			wantSamples: 10,
+		},
+		{
+			name:               "single series bigger than the budget keeps its latest samples",
+			limits:             resultLimits{Limit: 1, MaxSamples: 4},
+			wantJobs:           []string{"a"},
+			wantSamples:        4,
+			wantDroppedSamples: 6,
+			wantHasMore:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, pagination := applyResultLimits(matrix, tt.limits)
+			got := result.(model.Matrix)
+
+			if len(got) != len(tt.wantJobs) {
+				t.Fatalf("got %d series, want %d", len(got), len(tt.wantJobs))
+			}
+			for i, job := range tt.wantJobs {
+				if string(got[i].Metric["job"]) != job {
+					t.Errorf("series %d job = %s, want %s", i, got[i].Metric["job"], job)
+				}
+				if len(got[i].Values) != tt.wantSamples {
+					t.Errorf("series %d has %d samples, want %d", i, len(got[i].Values), tt.wantSamples)
+				}
+			}
+			if pagination["dropped_series"] != tt.wantDroppedSeries {
+				t.Errorf("dropped_series = %v, want %d", pagination["dropped_series"], tt.wantDroppedSeries)
+			}
+			if pagination["dropped_samples"] != tt.wantDroppedSamples {
+				t.Errorf("dropped_samples = %v, want %d", pagination["dropped_samples"], tt.wantDroppedSamples)
+			}
+			if pagination["has_more"] != tt.wantHasMore {
+				t.Errorf("has_more = %v, want %v", pagination["has_more"], tt.wantHasMore)
+			}
+		})
+	}
+
+	if got := matrix[0].Metric["job"]; got != "c" {
+		t.Errorf("input matrix was reordered, first job = %s", got)
+	}
+}
+
+func TestApplyResultLimitsKeepsVectorOrder(t *testing.T) {
+	// Result of sort_desc(up_requests), ranked by value rather than by label set
+	vector := model.Vector{
+		{Metric: model.Metric{"job": "b"}, Value: 30},
+		{Metric: model.Metric{"job": "c"}, Value: 20},
+		{Metric: model.Metric{"job": "a"}, Value: 10},
+	}
+
+	tests := []struct {
+		name     string
+		limits   resultLimits
+		wantJobs []string
+	}{
+		{
+			name:     "unlimited",
+			limits:   resultLimits{},
+			wantJobs: []string{"b", "c", "a"},
+		},
+		{
+			name:     "first page",
+			limits:   resultLimits{Limit: 2},
+			wantJobs: []string{"b", "c"},
+		},
+		{
+			name:     "second page",
+			limits:   resultLimits{Limit: 2, Offset: 2},
+			wantJobs: []string{"a"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, _ := applyResultLimits(vector, tt.limits)
+			got := result.(model.Vector)
+
+			if len(got) != len(tt.wantJobs) {
+				t.Fatalf("got %d samples, want %d", len(got), len(tt.wantJobs))
+			}
+			for i, job := range tt.wantJobs {
+				if string(got[i].Metric["job"]) != job {
+					t.Errorf("sample %d job = %s, want %s", i, got[i].Metric["job"], job)
+				}
+			}
+		})
+	}
+}
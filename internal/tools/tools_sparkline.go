@@ -0,0 +1,118 @@
+package tools
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/model"
+)
+
+const (
+	defaultSparklineWidth = 40
+	maxSparklineWidth     = 200
+)
+
+// sparklineLevels are the block characters used for each value, lowest first
+var sparklineLevels = []rune("▁▂▃▄▅▆▇█")
+
+// renderSparklines returns one line per series with a sparkline of its values over the query window,
+// so every line shares the same time axis, followed by its min, max and last values and its labels.
+// The width is capped to the number of steps, as wider lines would only show gaps between points
+func renderSparklines(matrix model.Matrix, start, end time.Time, step time.Duration, width int) string {
+	if len(matrix) == 0 {
+		return "(no series)"
+	}
+	if step > 0 {
+		width = min(width, int(end.Sub(start)/step)+1)
+	}
+	width = max(width, 1)
+
+	type sparklineRow struct {
+		line, min, max, last, series string
+	}
+
+	rows := make([]sparklineRow, 0, len(matrix))
+	for _, stream := range matrix {
+		row := sparklineRow{line: sparkline(stream.Values, start, end, width), series: stream.Metric.String()}
+		if stats := computeSeriesStats(stream.Values); stats.Count > 0 {
+			row.min = formatSparklineValue(stats.Min)
+			row.max = formatSparklineValue(stats.Max)
+			row.last = formatSparklineValue(stats.Last)
+		} else {
+			row.min, row.max, row.last = "-", "-", "-"
+		}
+		rows = append(rows, row)
+	}
+
+	// Pad the annotations so sparklines and labels line up in columns
+	var minWidth, maxWidth, lastWidth int
+	for _, row := range rows {
+		minWidth = max(minWidth, len(row.min))
+		maxWidth = max(maxWidth, len(row.max))
+		lastWidth = max(lastWidth, len(row.last))
+	}
+
+	lines := make([]string, 0, len(rows))
+	for _, row := range rows {
+		lines = append(lines, fmt.Sprintf("%s  min=%-*s max=%-*s last=%-*s  %s",
+			row.line, minWidth, row.min, maxWidth, row.max, lastWidth, row.last, row.series))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// sparkline splits the window in width buckets and draws the mean of each one, scaled between the series
+// min and max. Buckets without samples are left blank
+func sparkline(values []model.SamplePair, start, end time.Time, width int) string {
+	sums := make([]float64, width)
+	counts := make([]int, width)
+
+	from := model.TimeFromUnixNano(start.UnixNano())
+	span := float64(model.TimeFromUnixNano(end.UnixNano()) - from)
+	for _, sample := range values {
+		value := float64(sample.Value)
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			continue
+		}
+
+		bucket := 0
+		if span > 0 {
+			bucket = int(float64(sample.Timestamp-from) / span * float64(width))
+		}
+		bucket = min(max(bucket, 0), width-1)
+		sums[bucket] += value
+		counts[bucket]++
+	}
+
+	minValue, maxValue := math.Inf(1), math.Inf(-1)
+	for i := range sums {
+		if counts[i] == 0 {
+			continue
+		}
+		sums[i] /= float64(counts[i])
+		minValue = math.Min(minValue, sums[i])
+		maxValue = math.Max(maxValue, sums[i])
+	}
+
+	var b strings.Builder
+	for i := range sums {
+		if counts[i] == 0 {
+			b.WriteRune(' ')
+			continue
+		}
+
+		// Flat series are drawn at the bottom
+		level := 0
+		if maxValue > minValue {
+			level = int(math.Round((sums[i] - minValue) / (maxValue - minValue) * float64(len(sparklineLevels)-1)))
+		}
+		b.WriteRune(sparklineLevels[level])
+	}
+	return b.String()
+}
+
+func formatSparklineValue(value float64) string {
+	return strconv.FormatFloat(value, 'g', 4, 64)
+}
@@ -0,0 +1,60 @@
+package tools
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+)
+
+func TestSparkline(t *testing.T) {
+	start := time.Unix(0, 0)
+	end := start.Add(7 * time.Minute)
+
+	values := []model.SamplePair{}
+	for i, v := range []float64{0, 1, 2, 3, math.NaN(), 5, 6, 7} {
+		values = append(values, model.SamplePair{Timestamp: model.TimeFromUnix(int64(i * 60)), Value: model.SampleValue(v)})
+	}
+
+	if got := sparkline(values, start, end, 8); got != "▁▂▃▄ ▆▇█" {
+		t.Errorf("got %q, want %q", got, "▁▂▃▄ ▆▇█")
+	}
+
+	// Two samples per bucket are averaged
+	if got := sparkline(values, start, end, 4); got != "▁▃▆█" {
+		t.Errorf("got %q, want %q", got, "▁▃▆█")
+	}
+
+	flat := []model.SamplePair{{Timestamp: 0, Value: 3}, {Timestamp: 420000, Value: 3}}
+	if got := sparkline(flat, start, end, 3); got != "▁ ▁" {
+		t.Errorf("got %q, want %q", got, "▁ ▁")
+	}
+}
+
+func TestRenderSparklines(t *testing.T) {
+	start := time.Unix(0, 0)
+	end := start.Add(2 * time.Minute)
+
+	matrix := model.Matrix{
+		{
+			Metric: model.Metric{"job": "api"},
+			Values: []model.SamplePair{{Timestamp: 0, Value: 1}, {Timestamp: 60000, Value: 10}, {Timestamp: 120000, Value: 5}},
+		},
+		{
+			Metric: model.Metric{"job": "db"},
+		},
+	}
+
+	// The width is capped to the 3 steps of the window
+	got := renderSparklines(matrix, start, end, time.Minute, 40)
+	want := "▁█▄  min=1 max=10 last=5  {job=\"api\"}\n     min=- max=-  last=-  {job=\"db\"}"
+	if got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+
+	if !strings.Contains(renderSparklines(nil, start, end, time.Minute, 40), "no series") {
+		t.Error("empty matrix not reported")
+	}
+}
@@ -0,0 +1,162 @@
+package tools
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/common/model"
+)
+
+// seriesStats represents the statistics of the samples of a single series
+type seriesStats struct {
+	Count          int
+	Min            float64
+	Max            float64
+	Mean           float64
+	Last           float64
+	P50            float64
+	P95            float64
+	P99            float64
+	Stddev         float64
+	FirstTimestamp model.Time
+	LastTimestamp  model.Time
+}
+
+// seriesStatNames are the statistics series can be ranked by
+var seriesStatNames = []string{"min", "max", "mean", "last", "p50", "p95", "p99", "stddev", "count"}
+
+// computeSeriesStats computes the statistics of a series, ignoring NaN samples (e.g., stale markers)
+func computeSeriesStats(values []model.SamplePair) seriesStats {
+	stats := seriesStats{
+		Min:    math.NaN(),
+		Max:    math.NaN(),
+		Mean:   math.NaN(),
+		Last:   math.NaN(),
+		P50:    math.NaN(),
+		P95:    math.NaN(),
+		P99:    math.NaN(),
+		Stddev: math.NaN(),
+	}
+
+	sorted := make([]float64, 0, len(values))
+	var sum float64
+	for _, pair := range values {
+		value := float64(pair.Value)
+		if math.IsNaN(value) {
+			continue
+		}
+
+		if stats.Count == 0 || value < stats.Min {
+			stats.Min = value
+		}
+		if stats.Count == 0 || value > stats.Max {
+			stats.Max = value
+		}
+		if stats.Count == 0 {
+			stats.FirstTimestamp = pair.Timestamp
+		}
+		sum += value
+		stats.Last = value
+		stats.LastTimestamp = pair.Timestamp
+		stats.Count++
+		sorted = append(sorted, value)
+	}
+
+	if stats.Count == 0 {
+		return stats
+	}
+
+	stats.Mean = sum / float64(stats.Count)
+
+	var squares float64
+	for _, value := range sorted {
+		squares += (value - stats.Mean) * (value - stats.Mean)
+	}
+	stats.Stddev = math.Sqrt(squares / float64(stats.Count))
+
+	sort.Float64s(sorted)
+	stats.P50 = quantile(sorted, 0.50)
+	stats.P95 = quantile(sorted, 0.95)
+	stats.P99 = quantile(sorted, 0.99)
+
+	return stats
+}
+
+// quantile returns the q-quantile of sorted values, interpolating linearly between the closest ranks
+func quantile(sorted []float64, q float64) float64 {
+	if len(sorted) == 0 {
+		return math.NaN()
+	}
+
+	rank := q * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	if lower == upper {
+		return sorted[lower]
+	}
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower))
+}
+
+// stat returns a statistic by name, as listed in seriesStatNames
+func (s seriesStats) stat(name string) (float64, error) {
+	switch name {
+	case "min":
+		return s.Min, nil
+	case "max":
+		return s.Max, nil
+	case "mean":
+		return s.Mean, nil
+	case "last":
+		return s.Last, nil
+	case "p50":
+		return s.P50, nil
+	case "p95":
+		return s.P95, nil
+	case "p99":
+		return s.P99, nil
+	case "stddev":
+		return s.Stddev, nil
+	case "count":
+		return float64(s.Count), nil
+	}
+	return 0, fmt.Errorf("unknown statistic %q", name)
+}
+
+// summaryRow renders the statistics of a series as a flat row, rounded to the given significant digits
+func (s seriesStats) summaryRow(series string, digits int) map[string]interface{} {
+	row := map[string]interface{}{
+		"series":          series,
+		"count":           s.Count,
+		"min":             roundFloat(s.Min, digits),
+		"max":             roundFloat(s.Max, digits),
+		"mean":            roundFloat(s.Mean, digits),
+		"last":            roundFloat(s.Last, digits),
+		"p50":             roundFloat(s.P50, digits),
+		"p95":             roundFloat(s.P95, digits),
+		"p99":             roundFloat(s.P99, digits),
+		"stddev":          roundFloat(s.Stddev, digits),
+		"first_timestamp": "",
+		"last_timestamp":  "",
+	}
+	if s.Count > 0 {
+		row["first_timestamp"] = s.FirstTimestamp.Time().UTC().Format(time.RFC3339)
+		row["last_timestamp"] = s.LastTimestamp.Time().UTC().Format(time.RFC3339)
+	}
+	return row
+}
+
+// roundFloat rounds a value to the given number of significant digits, so small values such as sub-millisecond
+// latencies keep their precision. Returns nil for NaN and infinities so they are encoded as null
+func roundFloat(value float64, digits int) interface{} {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return nil
+	}
+	rounded, err := strconv.ParseFloat(strconv.FormatFloat(value, 'g', digits, 64), 64)
+	if err != nil {
+		return value
+	}
+	return rounded
+}
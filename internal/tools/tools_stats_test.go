@@ -0,0 +1,85 @@
+package tools
+
+import (
+	"math"
+	"testing"
+
+	"github.com/prometheus/common/model"
+)
+
+func TestComputeSeriesStats(t *testing.T) {
+	values := []model.SamplePair{
+		{Timestamp: 1000, Value: 4},
+		{Timestamp: 2000, Value: model.SampleValue(math.NaN())},
+		{Timestamp: 3000, Value: 2},
+		{Timestamp: 4000, Value: 6},
+		{Timestamp: 5000, Value: 8},
+	}
+
+	stats := computeSeriesStats(values)
+
+	want := map[string]float64{
+		"count":  4,
+		"min":    2,
+		"max":    8,
+		"mean":   5,
+		"last":   8,
+		"p50":    5,
+		"p95":    7.7,
+		"stddev": math.Sqrt(5),
+	}
+	for name, expected := range want {
+		got, err := stats.stat(name)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if math.Abs(got-expected) > 1e-9 {
+			t.Errorf("%s = %v, want %v", name, got, expected)
+		}
+	}
+
+	if stats.FirstTimestamp != 1000 || stats.LastTimestamp != 5000 {
+		t.Errorf("timestamps = %v..%v, want 1000..5000", stats.FirstTimestamp, stats.LastTimestamp)
+	}
+}
+
+func TestComputeSeriesStatsEmpty(t *testing.T) {
+	stats := computeSeriesStats(nil)
+	if stats.Count != 0 || !math.IsNaN(stats.Mean) {
+		t.Errorf("got %+v, want no samples and NaN mean", stats)
+	}
+}
+
+func TestRoundFloat(t *testing.T) {
+	tests := []struct {
+		value float64
+		want  interface{}
+	}{
+		{value: 0.0000012345678, want: 0.00000123457},
+		{value: -0.0000030001, want: -0.0000030001},
+		{value: 123456.789, want: 123457.0},
+		{value: 1.5e300, want: 1.5e300},
+		{value: 0, want: 0.0},
+		{value: math.NaN(), want: nil},
+		{value: math.Inf(1), want: nil},
+	}
+
+	for _, tt := range tests {
+		if got := roundFloat(tt.value, 6); got != tt.want {
+			t.Errorf("roundFloat(%v, 6) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestSummaryRowSmallValues(t *testing.T) {
+	values := []model.SamplePair{
+		{Timestamp: 1000, Value: 0.000021},
+		{Timestamp: 2000, Value: 0.000035},
+	}
+
+	row := computeSeriesStats(values).summaryRow(`{job="rpc"}`, summaryDigits)
+
+	if row["min"] != 0.000021 || row["max"] != 0.000035 || row["mean"] != 0.000028 {
+		t.Errorf("row = %v, want min 2.1e-05, max 3.5e-05 and mean 2.8e-05", row)
+	}
+}
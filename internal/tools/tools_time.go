@@ -0,0 +1,131 @@
+package tools
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	// Embedded so timezones resolve on images without a zoneinfo database
+	_ "time/tzdata"
+
+	"github.com/prometheus/common/model"
+)
+
+// timeLayouts are the absolute time formats accepted besides unix timestamps.
+// Layouts without offset are interpreted in the requested timezone
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+// loadTimezone returns the location of an IANA timezone name, UTC when empty
+func loadTimezone(timezone string) (*time.Location, error) {
+	if timezone == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q, use an IANA name (e.g., 'Europe/Madrid'): %w", timezone, err)
+	}
+	return loc, nil
+}
+
+// parseTime parses an absolute or relative time expression:
+//   - RFC3339 ("2024-01-15T10:00:00Z"), or a date/time without offset in the given location ("2024-01-15 10:00")
+//   - Unix timestamps in seconds ("1705312800", "1705312800.5")
+//   - "now", optionally shifted ("now-1h", "now+30m") and rounded down to a unit ("now/d", "now-1d/d")
+//   - Bare durations, meaning that long ago ("2h", "7d")
+//
+// Rounding units are s, m, h, d, w (weeks start on Monday), M (month) and y, computed in the given location
+func parseTime(value string, now time.Time, loc *time.Location) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, fmt.Errorf("empty time")
+	}
+	now = now.In(loc)
+
+	if strings.HasPrefix(value, "now") {
+		return parseRelativeTime(value, now, loc)
+	}
+
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil && !math.IsNaN(seconds) && !math.IsInf(seconds, 0) {
+		whole, fraction := math.Modf(seconds)
+		return time.Unix(int64(whole), int64(fraction*1e9)).In(loc), nil
+	}
+
+	if duration, err := model.ParseDuration(value); err == nil {
+		return now.Add(-time.Duration(duration)), nil
+	}
+
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid time %q, use RFC3339, unix seconds, 'now', 'now-1h', 'now/d' or a duration like '2h'", value)
+}
+
+// parseRelativeTime parses expressions starting with "now"
+func parseRelativeTime(value string, now time.Time, loc *time.Location) (time.Time, error) {
+	expr := strings.TrimPrefix(value, "now")
+
+	expr, roundUnit, rounded := strings.Cut(expr, "/")
+
+	result := now
+	if expr != "" {
+		sign := expr[0]
+		if sign != '-' && sign != '+' {
+			return time.Time{}, fmt.Errorf("invalid time %q, use 'now-<duration>' or 'now+<duration>'", value)
+		}
+		duration, err := model.ParseDuration(expr[1:])
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid duration in time %q: %w", value, err)
+		}
+		if sign == '-' {
+			result = result.Add(-time.Duration(duration))
+		} else {
+			result = result.Add(time.Duration(duration))
+		}
+	}
+
+	if rounded {
+		var err error
+		result, err = roundTime(result, roundUnit, loc)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid time %q: %w", value, err)
+		}
+	}
+
+	return result, nil
+}
+
+// roundTime rounds a time down to the start of the given unit in the given location
+func roundTime(t time.Time, unit string, loc *time.Location) (time.Time, error) {
+	t = t.In(loc)
+	year, month, day := t.Date()
+
+	switch unit {
+	case "s":
+		return t.Truncate(time.Second), nil
+	case "m":
+		return time.Date(year, month, day, t.Hour(), t.Minute(), 0, 0, loc), nil
+	case "h":
+		return time.Date(year, month, day, t.Hour(), 0, 0, 0, loc), nil
+	case "d":
+		return time.Date(year, month, day, 0, 0, 0, 0, loc), nil
+	case "w":
+		daysSinceMonday := (int(t.Weekday()) + 6) % 7
+		return time.Date(year, month, day-daysSinceMonday, 0, 0, 0, 0, loc), nil
+	case "M":
+		return time.Date(year, month, 1, 0, 0, 0, 0, loc), nil
+	case "y":
+		return time.Date(year, time.January, 1, 0, 0, 0, 0, loc), nil
+	}
+
+	return time.Time{}, fmt.Errorf("unknown rounding unit %q, use one of: s, m, h, d, w, M, y", unit)
+}
@@ -0,0 +1,53 @@
+package tools
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTime(t *testing.T) {
+	madrid, err := time.LoadLocation("Europe/Madrid")
+	if err != nil {
+		t.Skipf("timezone database not available: %v", err)
+	}
+
+	// Wednesday
+	now := time.Date(2024, 1, 17, 15, 42, 10, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		value   string
+		loc     *time.Location
+		want    time.Time
+		wantErr bool
+	}{
+		{name: "rfc3339", value: "2024-01-15T10:00:00Z", loc: time.UTC, want: time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)},
+		{name: "date in timezone", value: "2024-01-15", loc: madrid, want: time.Date(2024, 1, 15, 0, 0, 0, 0, madrid)},
+		{name: "unix seconds", value: "1705312800", loc: time.UTC, want: time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)},
+		{name: "now", value: "now", loc: time.UTC, want: now},
+		{name: "now minus", value: "now-1h", loc: time.UTC, want: now.Add(-time.Hour)},
+		{name: "now plus", value: "now+30m", loc: time.UTC, want: now.Add(30 * time.Minute)},
+		{name: "bare duration", value: "2h", loc: time.UTC, want: now.Add(-2 * time.Hour)},
+		{name: "bare days", value: "7d", loc: time.UTC, want: now.Add(-7 * 24 * time.Hour)},
+		{name: "round to day", value: "now/d", loc: time.UTC, want: time.Date(2024, 1, 17, 0, 0, 0, 0, time.UTC)},
+		{name: "round to day in timezone", value: "now/d", loc: madrid, want: time.Date(2024, 1, 17, 0, 0, 0, 0, madrid)},
+		{name: "yesterday", value: "now-1d/d", loc: time.UTC, want: time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC)},
+		{name: "round to week", value: "now/w", loc: time.UTC, want: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)},
+		{name: "round to month", value: "now/M", loc: time.UTC, want: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "unknown unit", value: "now/q", loc: time.UTC, wantErr: true},
+		{name: "garbage", value: "yesterday", loc: time.UTC, wantErr: true},
+		{name: "missing sign", value: "now1h", loc: time.UTC, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseTime(tt.value, now, tt.loc)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseTime(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if !tt.wantErr && !got.Equal(tt.want) {
+				t.Errorf("parseTime(%q) = %s, want %s", tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package tools
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/prometheus/common/model"
+)
+
+const (
+	defaultTimeWindow = time.Hour
+
+	// defaultMaxPoints is the number of points per series used to compute the step when not configured
+	defaultMaxPoints = 500
+
+	// maxPointsPerSeries is the hard limit enforced by Prometheus on range queries
+	maxPointsPerSeries = 11000
+)
+
+// alignedSteps are the step boundaries automatic steps are rounded up to
+var alignedSteps = []time.Duration{
+	time.Second, 2 * time.Second, 5 * time.Second, 10 * time.Second, 15 * time.Second, 30 * time.Second,
+	time.Minute, 2 * time.Minute, 5 * time.Minute, 10 * time.Minute, 15 * time.Minute, 30 * time.Minute,
+	time.Hour, 2 * time.Hour, 3 * time.Hour, 6 * time.Hour, 12 * time.Hour,
+	24 * time.Hour, 2 * 24 * time.Hour, 7 * 24 * time.Hour,
+}
+
+// paginationBounds returns the slice bounds for the requested page, clamped to the total
+func paginationBounds(total, offset, limit int) (int, int) {
+	start := offset
+	end := offset + limit
+
+	if start > total {
+		start = total
+	}
+	if end > total {
+		end = total
+	}
+
+	return start, end
+}
+
+// parseTimeWindow parses optional start/end arguments with parseTime, in the given timezone.
+// When missing, end defaults to now and start to one window before end
+func parseTimeWindow(start, end, timezone string) (time.Time, time.Time, error) {
+	loc, err := loadTimezone(timezone)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	now := time.Now()
+
+	endTime := now.In(loc)
+	if end != "" {
+		endTime, err = parseTime(end, now, loc)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid end time: %w", err)
+		}
+	}
+
+	startTime := endTime.Add(-defaultTimeWindow)
+	if start != "" {
+		startTime, err = parseTime(start, now, loc)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid start time: %w", err)
+		}
+	}
+
+	if startTime.After(endTime) {
+		return time.Time{}, time.Time{}, fmt.Errorf("start time must be before end time")
+	}
+
+	return startTime, endTime, nil
+}
+
+// resolveStep returns the step of a range query and how it was chosen. An explicit step is kept as long as
+// it stays within the Prometheus points limit, otherwise the step is computed from the points budget
+// and rounded up to an aligned boundary
+func resolveStep(stepArg string, maxPoints int, startTime, endTime time.Time) (time.Duration, string, error) {
+	if maxPoints <= 0 || maxPoints > maxPointsPerSeries {
+		maxPoints = maxPointsPerSeries
+	}
+	window := endTime.Sub(startTime)
+
+	if stepArg != "" {
+		modelStep, err := model.ParseDuration(stepArg)
+		if err != nil {
+			return 0, "", fmt.Errorf("invalid step duration: %w", err)
+		}
+		step := time.Duration(modelStep)
+		if step <= 0 {
+			return 0, "", fmt.Errorf("step must be positive")
+		}
+		if int64(window/step) < maxPointsPerSeries {
+			return step, "explicit", nil
+		}
+		return alignStep(window, maxPointsPerSeries), fmt.Sprintf("raised to stay under %d points", maxPointsPerSeries), nil
+	}
+
+	return alignStep(window, maxPoints), fmt.Sprintf("auto, max %d points", maxPoints), nil
+}
+
+// maxPoints returns the points budget of a range query: the argument, the configured default or the built-in one
+func (tm *ToolsManager) maxPoints(maxPointsArg int) int {
+	if maxPointsArg > 0 {
+		return maxPointsArg
+	}
+	if tm.dependencies.AppCtx.Config.Tools.MaxPoints > 0 {
+		return tm.dependencies.AppCtx.Config.Tools.MaxPoints
+	}
+	return defaultMaxPoints
+}
+
+// alignStep returns the smallest aligned step producing at most maxPoints points over the window
+func alignStep(window time.Duration, maxPoints int) time.Duration {
+	// A range query returns one point per step plus the starting one
+	intervals := int64(maxPoints - 1)
+	if intervals < 1 {
+		intervals = 1
+	}
+	minStep := time.Duration((int64(window) + intervals - 1) / intervals)
+
+	for _, step := range alignedSteps {
+		if step >= minStep {
+			return step
+		}
+	}
+
+	// Beyond the biggest boundary, round up to whole days
+	day := 24 * time.Hour
+	return (minStep + day - 1) / day * day
+}
+
+// newNameFilter builds a predicate from an optional glob pattern and an optional regular expression.
+// Both must match when both are set
+func newNameFilter(glob, regex string) (func(string) bool, error) {
+	if glob != "" {
+		if _, err := filepath.Match(glob, ""); err != nil {
+			return nil, fmt.Errorf("invalid glob pattern: %w", err)
+		}
+	}
+
+	var re *regexp.Regexp
+	if regex != "" {
+		var err error
+		re, err = regexp.Compile(regex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regex: %w", err)
+		}
+	}
+
+	return func(name string) bool {
+		if glob != "" {
+			if matched, _ := filepath.Match(glob, name); !matched {
+				return false
+			}
+		}
+		if re != nil && !re.MatchString(name) {
+			return false
+		}
+		return true
+	}, nil
+}
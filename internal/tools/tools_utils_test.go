@@ -0,0 +1,83 @@
+package tools
+
+import (
+	"testing"
+	"time"
+)
+
+func TestResolveStep(t *testing.T) {
+	end := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		step      string
+		maxPoints int
+		window    time.Duration
+		wantStep  time.Duration
+		wantErr   bool
+	}{
+		{
+			name:      "auto step over one hour",
+			maxPoints: 500,
+			window:    time.Hour,
+			wantStep:  10 * time.Second,
+		},
+		{
+			name:      "auto step over thirty days",
+			maxPoints: 500,
+			window:    30 * 24 * time.Hour,
+			wantStep:  2 * time.Hour,
+		},
+		{
+			name:      "auto step with small budget",
+			maxPoints: 60,
+			window:    time.Hour,
+			wantStep:  2 * time.Minute,
+		},
+		{
+			name:      "budget above the prometheus limit",
+			maxPoints: 50000,
+			window:    30 * 24 * time.Hour,
+			wantStep:  5 * time.Minute,
+		},
+		{
+			name:      "explicit step is kept",
+			step:      "30s",
+			maxPoints: 10,
+			window:    time.Hour,
+			wantStep:  30 * time.Second,
+		},
+		{
+			name:      "explicit step is raised",
+			step:      "1s",
+			maxPoints: 500,
+			window:    30 * 24 * time.Hour,
+			wantStep:  5 * time.Minute,
+		},
+		{
+			name:      "explicit step in days",
+			step:      "1d",
+			maxPoints: 500,
+			window:    30 * 24 * time.Hour,
+			wantStep:  24 * time.Hour,
+		},
+		{
+			name:    "invalid step",
+			step:    "soon",
+			window:  time.Hour,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, _, err := resolveStep(tt.step, tt.maxPoints, end.Add(-tt.window), end)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveStep() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if step != tt.wantStep {
+				t.Errorf("resolveStep() = %s, want %s", step, tt.wantStep)
+			}
+		})
+	}
+}